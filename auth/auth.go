@@ -0,0 +1,193 @@
+// Package auth verifies the HMAC-signed JWT bearer tokens callers send in
+// gRPC metadata and carries the resulting identity through the request
+// context.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AdminRole lets a caller act on resources owned by anyone.
+const AdminRole = "admin"
+
+// Identity is the verified caller of an RPC.
+type Identity struct {
+	// Subject is the token's "sub" claim, the caller's author ID.
+	Subject string
+	Roles   []string
+}
+
+// IsAdmin reports whether the identity holds AdminRole.
+func (id Identity) IsAdmin() bool {
+	for _, role := range id.Roles {
+		if role == AdminRole {
+			return true
+		}
+	}
+	return false
+}
+
+type claims struct {
+	Roles []string `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type identityKey struct{}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the identity a Verifier stored in ctx, if any.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// LoadKey reads an HMAC key from a file, ignoring surrounding whitespace.
+func LoadKey(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key := []byte(strings.TrimSpace(string(data)))
+	if len(key) == 0 {
+		return nil, fmt.Errorf("auth key file %s is empty", path)
+	}
+	return key, nil
+}
+
+// NewToken signs a token for subject with the given roles, valid for ttl.
+func NewToken(key []byte, subject string, roles []string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	c := claims{
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString(key)
+}
+
+// Verifier checks bearer tokens against an HMAC key.
+type Verifier struct {
+	key    []byte
+	public map[string]bool
+}
+
+// NewVerifier returns a Verifier for tokens signed with key. Calls to the
+// public methods, given as full names like "/blog.BlogService/ReadBlog" or
+// as whole services like "/greet.GreetService/", may omit the token; a
+// token that is sent is still verified.
+func NewVerifier(key []byte, publicMethods ...string) *Verifier {
+	v := &Verifier{key: key, public: make(map[string]bool)}
+	for _, method := range publicMethods {
+		v.public[method] = true
+	}
+	return v
+}
+
+// Verify parses token and returns the identity it was issued to. Tokens
+// without an expiry are rejected, as they could never be revoked.
+func (v *Verifier) Verify(token string) (Identity, error) {
+	c := &claims{}
+	_, err := jwt.ParseWithClaims(token, c, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return v.key, nil
+	})
+	if err != nil {
+		return Identity{}, err
+	}
+	if c.ExpiresAt == nil {
+		return Identity{}, errors.New("token has no expiry")
+	}
+	if c.Subject == "" {
+		return Identity{}, errors.New("token has no subject")
+	}
+	return Identity{Subject: c.Subject, Roles: c.Roles}, nil
+}
+
+// authenticate returns ctx with the caller's identity attached.
+func (v *Verifier) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		if v.public[method] || v.public[method[:strings.LastIndex(method, "/")+1]] {
+			return ctx, nil
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "Missing bearer token")
+	}
+	token := strings.TrimSpace(values[0])
+	if len(token) < 7 || !strings.EqualFold(token[:7], "bearer ") {
+		return nil, status.Errorf(codes.Unauthenticated, "Authorization must be a bearer token")
+	}
+	id, err := v.Verify(strings.TrimSpace(token[7:]))
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid bearer token: %v", err)
+	}
+	return NewContext(ctx, id), nil
+}
+
+// UnaryServerInterceptor rejects unary calls without a valid token.
+func (v *Verifier) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := v.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streaming calls without a valid token.
+func (v *Verifier) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := v.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// TokenCredentials sends a bearer token with every call. Use it with
+// grpc.WithPerRPCCredentials.
+type TokenCredentials struct {
+	Token string
+	// Insecure allows sending the token over connections without TLS.
+	Insecure bool
+}
+
+func (c TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.Token}, nil
+}
+
+func (c TokenCredentials) RequireTransportSecurity() bool {
+	return !c.Insecure
+}
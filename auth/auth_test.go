@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerify(t *testing.T) {
+	key := []byte("secret")
+	v := NewVerifier(key)
+	sign := func(c claims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString(key)
+		if err != nil {
+			t.Fatalf("signing: %v", err)
+		}
+		return token
+	}
+	valid, err := NewToken(key, "ann", []string{AdminRole}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewToken: %v", err)
+	}
+	id, err := v.Verify(valid)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if id.Subject != "ann" || !id.IsAdmin() {
+		t.Errorf("got identity %+v, want admin ann", id)
+	}
+
+	expired, _ := NewToken(key, "ann", nil, -time.Minute)
+	otherKey, _ := NewToken([]byte("other"), "ann", nil, time.Hour)
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"expired", expired},
+		{"other key", otherKey},
+		{"no expiry", sign(claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "ann"}})},
+		{"no subject", sign(claims{RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}})},
+		{"not a token", "abc"},
+	}
+	for _, tt := range tests {
+		if _, err := v.Verify(tt.token); err == nil {
+			t.Errorf("%s: token was accepted", tt.name)
+		}
+	}
+}
+
+// stream is a streaming call with a context of its own.
+type stream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *stream) Context() context.Context {
+	return s.ctx
+}
+
+func TestVerifierInterceptors(t *testing.T) {
+	key := []byte("secret")
+	v := NewVerifier(key, "/blog.BlogService/ReadBlog", "/greet.GreetService/")
+	token, _ := NewToken(key, "ann", nil, time.Hour)
+	otherKey, _ := NewToken([]byte("other"), "ann", nil, time.Hour)
+
+	tests := []struct {
+		name   string
+		method string
+		// auth is the authorization header, none when empty.
+		auth string
+		// want is the subject the handler sees, "" for none.
+		want string
+		code codes.Code
+	}{
+		{"valid token", "/blog.BlogService/CreateBlog", "Bearer " + token, "ann", codes.OK},
+		{"scheme in any case", "/blog.BlogService/CreateBlog", "bearer " + token, "ann", codes.OK},
+		{"missing token", "/blog.BlogService/CreateBlog", "", "", codes.Unauthenticated},
+		{"not a bearer token", "/blog.BlogService/CreateBlog", "Basic " + token, "", codes.Unauthenticated},
+		{"wrong key", "/blog.BlogService/CreateBlog", "Bearer " + otherKey, "", codes.Unauthenticated},
+		{"garbage", "/blog.BlogService/CreateBlog", "Bearer abc", "", codes.Unauthenticated},
+		{"public method", "/blog.BlogService/ReadBlog", "", "", codes.OK},
+		{"public service", "/greet.GreetService/Greet", "", "", codes.OK},
+		{"invalid token on a public method", "/blog.BlogService/ReadBlog", "Bearer " + otherKey, "", codes.Unauthenticated},
+		{"token on a public method", "/blog.BlogService/ReadBlog", "Bearer " + token, "ann", codes.OK},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.auth != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.auth))
+		}
+		subject := func(ctx context.Context) string {
+			id, _ := FromContext(ctx)
+			return id.Subject
+		}
+
+		var got string
+		_, err := v.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			got = subject(ctx)
+			return nil, nil
+		})
+		if status.Code(err) != tt.code || got != tt.want {
+			t.Errorf("%s, unary: got subject %q, %v; want %q, %v", tt.name, got, err, tt.want, tt.code)
+		}
+
+		got = ""
+		err = v.StreamServerInterceptor()(nil, &stream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: tt.method}, func(srv interface{}, ss grpc.ServerStream) error {
+			got = subject(ss.Context())
+			return nil
+		})
+		if status.Code(err) != tt.code || got != tt.want {
+			t.Errorf("%s, stream: got subject %q, %v; want %q, %v", tt.name, got, err, tt.want, tt.code)
+		}
+	}
+}
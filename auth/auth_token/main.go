@@ -0,0 +1,55 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+)
+
+// auth_token signs bearer tokens for local development, creating the key
+// file first when asked to.
+func main() {
+	keyFile := flag.String("key", "ssl/auth.key", "HMAC key file shared with the servers")
+	genKey := flag.Bool("genkey", false, "create a new random key file if it does not exist")
+	subject := flag.String("sub", "", "author ID the token is issued to")
+	roles := flag.String("roles", "", "comma-separated roles, e.g. admin")
+	ttl := flag.Duration("ttl", 24*time.Hour, "token lifetime")
+	flag.Parse()
+
+	if *genKey {
+		if _, err := os.Stat(*keyFile); os.IsNotExist(err) {
+			buf := make([]byte, 32)
+			if _, err := rand.Read(buf); err != nil {
+				log.Fatalf("Failed generating key: %v", err)
+			}
+			if err := ioutil.WriteFile(*keyFile, []byte(hex.EncodeToString(buf)+"\n"), 0600); err != nil {
+				log.Fatalf("Failed writing key: %v", err)
+			}
+			log.Printf("Wrote new key to %s", *keyFile)
+		}
+	}
+	if *subject == "" {
+		log.Fatal("-sub is required")
+	}
+	key, err := auth.LoadKey(*keyFile)
+	if err != nil {
+		log.Fatalf("Failed loading key: %v", err)
+	}
+	var roleList []string
+	if *roles != "" {
+		roleList = strings.Split(*roles, ",")
+	}
+	token, err := auth.NewToken(key, *subject, roleList, *ttl)
+	if err != nil {
+		log.Fatalf("Failed signing token: %v", err)
+	}
+	fmt.Println(token)
+}
@@ -1,237 +1,14 @@
 package main
 
 import (
-	"context"
-	"fmt"
-	"log"
-	"net"
-	"os"
-	"os/signal"
-
-	"github.com/joho/godotenv"
-	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	// "google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/reflection"
-	"google.golang.org/grpc/status"
+	"github.com/shivkumar123g/grpc_go_course/config"
+	"github.com/shivkumar123g/grpc_go_course/server"
 )
 
-var collection *mongo.Collection
-
-type server struct{}
-
-type blogItem struct {
-	ID       primitive.ObjectID `bson:"_id,omitempty"`
-	AuthorID string             `bson:"author_id"`
-	Content  string             `bson:"content"`
-	Title    string             `bson:"title"`
-}
-
-func (*server) CreateBlog(ctx context.Context, req *blogpb.CreateBlogRequest) (*blogpb.CreateBlogResponse, error) {
-	blog := req.GetBlog()
-	data := blogItem{
-		AuthorID: blog.GetAutherId(),
-		Content:  blog.GetContent(),
-		Title:    blog.GetTitle(),
-	}
-	res, err := collection.InsertOne(context.Background(), data)
-	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
-		)
-	}
-	oid, ok := res.InsertedID.(primitive.ObjectID)
-	if !ok {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprint("Cannot convert to OID"),
-		)
-	}
-	return &blogpb.CreateBlogResponse{
-		Blog: &blogpb.Blog{
-			Id:       oid.Hex(),
-			AutherId: blog.GetAutherId(),
-			Title:    blog.GetTitle(),
-			Content:  blog.GetContent(),
-		},
-	}, nil
-}
-
-func (*server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blogpb.ReadBlogResponse, error) {
-	blogID := req.GetBlogId()
-	oid, err := primitive.ObjectIDFromHex(blogID)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprint("Cannot parse ID"))
-	}
-	data := &blogItem{}
-	filter := bson.D{primitive.E{Key: "_id", Value: oid}}
-	res := collection.FindOne(context.Background(), filter)
-	if err := res.Decode(data); err != nil {
-		return nil, status.Errorf(
-			codes.NotFound,
-			fmt.Sprintf("Cannot find blog with specified ID: %v", err),
-		)
-	}
-	return &blogpb.ReadBlogResponse{
-		Blog: &blogpb.Blog{
-			Id:       data.ID.Hex(),
-			AutherId: data.AuthorID,
-			Title:    data.Title,
-			Content:  data.Content,
-		},
-	}, nil
-
-}
-
-func (*server) UpdateBlog(ctx context.Context, req *blogpb.UpdateBlogRequest) (*blogpb.UpdateBlogResponse, error) {
-	blog := req.GetBlog()
-	oid, err := primitive.ObjectIDFromHex(blog.GetId())
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprint("Cannot parse ID"))
-	}
-	data := bson.D{
-		primitive.E{
-			Key: "$set",
-			Value: bson.D{
-				primitive.E{
-					Key:   "author_id",
-					Value: blog.GetAutherId(),
-				},
-			},
-		},
-	}
-	filter := bson.D{primitive.E{Key: "_id", Value: oid}}
-	_, updateErr := collection.UpdateOne(context.Background(), filter, data)
-	if updateErr != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", updateErr),
-		)
-	}
-	return &blogpb.UpdateBlogResponse{
-		Blog: blog,
-	}, nil
-}
-
-func (*server) DeleteBlog(ctx context.Context, req *blogpb.DeleteBlogRequest) (*blogpb.DeleteBlogResponse, error) {
-	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprint("Cannot parse ID"))
-	}
-	filter := bson.D{primitive.E{Key: "_id", Value: oid}}
-	_, deleteErr := collection.DeleteOne(context.Background(), filter)
-	if deleteErr != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", deleteErr),
-		)
-	}
-	return &blogpb.DeleteBlogResponse{
-		BlogId: req.GetBlogId(),
-	}, nil
-}
-
-func (*server) ListBlog(req *blogpb.ListBlogRequest, stream blogpb.BlogService_ListBlogServer) error {
-	cur, err := collection.Find(context.Background(), bson.D{})
-	if err != nil {
-		return status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
-		)
-	}
-	defer cur.Close(context.Background())
-	for cur.Next(context.Background()) {
-		data := &blogItem{}
-		err := cur.Decode(data)
-		if err != nil {
-			return status.Errorf(
-				codes.Internal,
-				fmt.Sprintf("Decode error: %v", err),
-			)
-		}
-		stream.Send(&blogpb.ListBlogResponse{
-			Blog: &blogpb.Blog{
-				Id:       data.ID.Hex(),
-				AutherId: data.AuthorID,
-				Title:    data.Title,
-				Content:  data.Content,
-			},
-		})
-	}
-	if err := cur.Err() ; err !=nil {
-		return status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
-		)
-	}
-	return nil
-}
-
+// blog_server serves only the BlogService.
 func main() {
-	//if we crash the go code, we get the file name and line number
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-
-	//connect to mongodb
-	fmt.Println("Connecting to MongoDb")
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
-	}
-	client, err := mongo.NewClient(options.Client().ApplyURI(os.Getenv("DB_CONNECTION")))
-	if err != nil {
-		log.Fatal(err)
-	}
-	err = client.Connect(context.TODO())
-	if err != nil {
-		log.Fatal(err)
-	}
-	// err = client.Ping(context.TODO(), readpref.Primary())
-	// if err != nil {
-	// 	log.Fatal(err)
-	// }
-
-	collection = client.Database("mydb").Collection("blog")
-
-	lis, err := net.Listen("tcp", "0.0.0.0:50051")
-	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
-	}
-	// certFile := "ssl/server.crt"
-	// keyFile := "ssl/server.pem"
-	// creds, sslErr := credentials.NewServerTLSFromFile(certFile, keyFile)
-
-	// if sslErr != nil {
-	// 	log.Fatalf("Failed loading certificates: %v", sslErr)
-	// 	return
-	// }
-
-	s := grpc.NewServer()
-	// s := grpc.NewServer(grpc.Creds(creds))
-	blogpb.RegisterBlogServiceServer(s, &server{})
-    reflection.Register(s)
-	go func() {
-		if err := s.Serve(lis); err != nil {
-			log.Fatalf("failed to serve: %v", err)
-		}
-	}()
-	//Wait for Ctr + C for exit
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, os.Interrupt)
-
-	//Block until a signal is received
-	<-ch
-	fmt.Println("Stopping the server")
-	s.Stop()
-	fmt.Println("Closing the listener")
-	lis.Close()
-	fmt.Println("Closing the Mongodb Connection")
-	client.Disconnect(context.TODO())
-	fmt.Println("End of program")
-
+	defaults := config.Default()
+	defaults.Listen = "0.0.0.0:50053"
+	defaults.Services = config.Services{Blog: true}
+	server.Main("blog", defaults)
 }
@@ -0,0 +1,223 @@
+// Package blogclient is a typed client of the BlogService.
+package blogclient
+
+import (
+	"context"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"github.com/shivkumar123g/grpc_go_course/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Client calls the BlogService. Its errors are *client.Error values;
+// invalid blogs fail with client.ErrInvalidArgument and list the fields
+// in the error's Violations.
+type Client struct {
+	cc  *grpc.ClientConn
+	rpc blogpb.BlogServiceClient
+}
+
+// New returns a Client using cc, which the caller keeps ownership of.
+func New(cc grpc.ClientConnInterface) *Client {
+	return &Client{rpc: blogpb.NewBlogServiceClient(cc)}
+}
+
+// Dial connects to the server at target. Servers checking bearer tokens
+// need opts.Token for everything but reads.
+func Dial(ctx context.Context, target string, opts client.Options) (*Client, error) {
+	cc, err := client.Dial(ctx, target, opts)
+	if err != nil {
+		return nil, err
+	}
+	c := New(cc)
+	c.cc = cc
+	return c, nil
+}
+
+// Close closes the connection Dial opened.
+func (c *Client) Close() error {
+	if c.cc == nil {
+		return nil
+	}
+	return c.cc.Close()
+}
+
+// Create stores blog and returns it with its ID and version.
+func (c *Client) Create(ctx context.Context, blog *blogpb.Blog) (*blogpb.Blog, error) {
+	res, err := c.rpc.CreateBlog(ctx, &blogpb.CreateBlogRequest{Blog: blog})
+	if err != nil {
+		return nil, client.Convert(err)
+	}
+	return res.GetBlog(), nil
+}
+
+// Read returns the blog with the given ID.
+func (c *Client) Read(ctx context.Context, id string) (*blogpb.Blog, error) {
+	res, err := c.rpc.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: id})
+	if err != nil {
+		return nil, client.Convert(err)
+	}
+	return res.GetBlog(), nil
+}
+
+// UpdateOptions narrow an update.
+type UpdateOptions struct {
+	// Fields lists the fields of the blog to write, any of "auther_id",
+	// "title" and "content"; all of them when empty.
+	Fields []string
+	// ExpectedVersion, when non-zero, makes the update fail with
+	// client.ErrConflict unless the stored blog has this version.
+	ExpectedVersion int64
+}
+
+// Update writes blog over the stored one with the same ID and returns the
+// result.
+func (c *Client) Update(ctx context.Context, blog *blogpb.Blog, opts UpdateOptions) (*blogpb.Blog, error) {
+	req := &blogpb.UpdateBlogRequest{Blog: blog, ExpectedVersion: opts.ExpectedVersion}
+	if len(opts.Fields) > 0 {
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: opts.Fields}
+	}
+	res, err := c.rpc.UpdateBlog(ctx, req)
+	if err != nil {
+		return nil, client.Convert(err)
+	}
+	return res.GetBlog(), nil
+}
+
+// DeleteOptions change how a blog is deleted.
+type DeleteOptions struct {
+	// ExpectedVersion is as for UpdateOptions.
+	ExpectedVersion int64
+	// Purge removes the blog for good instead of soft-deleting it.
+	Purge bool
+}
+
+// Delete deletes the blog with the given ID.
+func (c *Client) Delete(ctx context.Context, id string, opts DeleteOptions) error {
+	_, err := c.rpc.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: id, ExpectedVersion: opts.ExpectedVersion, Purge: opts.Purge})
+	return client.Convert(err)
+}
+
+// Undelete restores a soft-deleted blog. expectedVersion is as for
+// UpdateOptions.
+func (c *Client) Undelete(ctx context.Context, id string, expectedVersion int64) (*blogpb.Blog, error) {
+	res, err := c.rpc.UndeleteBlog(ctx, &blogpb.UndeleteBlogRequest{BlogId: id, ExpectedVersion: expectedVersion})
+	if err != nil {
+		return nil, client.Convert(err)
+	}
+	return res.GetBlog(), nil
+}
+
+// List iterates over the blogs req selects, all of them when req is nil.
+func (c *Client) List(ctx context.Context, req *blogpb.ListBlogRequest) *client.Iterator[*blogpb.Blog] {
+	if req == nil {
+		req = &blogpb.ListBlogRequest{}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.rpc.ListBlog(ctx, req)
+	if err != nil {
+		return client.Failed[*blogpb.Blog](err, cancel)
+	}
+	return client.NewIterator(func() (*blogpb.Blog, error) {
+		res, err := stream.Recv()
+		return res.GetBlog(), err
+	}, cancel)
+}
+
+// ListPage returns a page of the blogs req selects and the token of the
+// next page, empty on the last one.
+func (c *Client) ListPage(ctx context.Context, req *blogpb.ListBlogRequest) ([]*blogpb.Blog, string, error) {
+	if req == nil {
+		req = &blogpb.ListBlogRequest{}
+	}
+	res, err := c.rpc.ListBlogsPage(ctx, req)
+	if err != nil {
+		return nil, "", client.Convert(err)
+	}
+	return res.GetBlogs(), res.GetNextPageToken(), nil
+}
+
+// Search returns the blogs matching query, best match first. pageSize
+// caps the results, 0 leaving it to the server; author, when set, only
+// searches the blogs of this author.
+func (c *Client) Search(ctx context.Context, query string, pageSize int32, author string) ([]*blogpb.SearchResult, error) {
+	res, err := c.rpc.SearchBlogs(ctx, &blogpb.SearchBlogsRequest{Query: query, PageSize: pageSize, AutherId: author})
+	if err != nil {
+		return nil, client.Convert(err)
+	}
+	return res.GetResults(), nil
+}
+
+// Watch iterates over blog changes as they happen, until ctx ends or the
+// iterator is closed. Pass the resume token of the last event seen to
+// pick up where an earlier watch stopped. A token the server can no longer
+// resume from, because it restarted or too many events passed since, fails
+// with client.ErrOutOfRange; watch again without one and reread what
+// matters.
+func (c *Client) Watch(ctx context.Context, req *blogpb.WatchBlogsRequest) *client.Iterator[*blogpb.BlogEvent] {
+	if req == nil {
+		req = &blogpb.WatchBlogsRequest{}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.rpc.WatchBlogs(ctx, req)
+	if err != nil {
+		return client.Failed[*blogpb.BlogEvent](err, cancel)
+	}
+	return client.NewIterator(stream.Recv, cancel)
+}
+
+// Result is the outcome of one item of a batch call.
+type Result struct {
+	// Blog is set when the item succeeded.
+	Blog *blogpb.Blog
+	// Err is why the item failed, a *client.Error.
+	Err error
+}
+
+func results(res []*blogpb.BlogResult) []Result {
+	out := make([]Result, len(res))
+	for i, r := range res {
+		out[i] = Result{
+			Blog: r.GetBlog(),
+			Err:  client.FromStatus(status.New(codes.Code(r.GetCode()), r.GetMessage())),
+		}
+	}
+	return out
+}
+
+// BatchCreate stores blogs, returning a result per blog in order. With
+// allOrNothing, it stores none of them and fails if any is invalid.
+func (c *Client) BatchCreate(ctx context.Context, blogs []*blogpb.Blog, allOrNothing bool) ([]Result, error) {
+	req := &blogpb.BatchCreateBlogsRequest{AllOrNothing: allOrNothing}
+	for _, blog := range blogs {
+		req.Requests = append(req.Requests, &blogpb.CreateBlogRequest{Blog: blog})
+	}
+	res, err := c.rpc.BatchCreateBlogs(ctx, req)
+	if err != nil {
+		return nil, client.Convert(err)
+	}
+	return results(res.GetResults()), nil
+}
+
+// BatchGet returns a result per ID in order, failing with
+// client.ErrNotFound for missing blogs.
+func (c *Client) BatchGet(ctx context.Context, ids []string) ([]Result, error) {
+	res, err := c.rpc.BatchGetBlogs(ctx, &blogpb.BatchGetBlogsRequest{BlogIds: ids})
+	if err != nil {
+		return nil, client.Convert(err)
+	}
+	return results(res.GetResults()), nil
+}
+
+// BatchDelete deletes the blogs with the given IDs, returning a result
+// per ID in order holding the deleted blog.
+func (c *Client) BatchDelete(ctx context.Context, ids []string, purge bool) ([]Result, error) {
+	res, err := c.rpc.BatchDeleteBlogs(ctx, &blogpb.BatchDeleteBlogsRequest{BlogIds: ids, Purge: purge})
+	if err != nil {
+		return nil, client.Convert(err)
+	}
+	return results(res.GetResults()), nil
+}
@@ -0,0 +1,91 @@
+package blogsvc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PublicMethods may be called without a bearer token.
+var PublicMethods = []string{
+	"/blog.BlogService/ReadBlog",
+	"/blog.BlogService/ListBlog",
+	"/blog.BlogService/ListBlogsPage",
+	"/blog.BlogService/SearchBlogs",
+	"/blog.BlogService/WatchBlogs",
+	"/blog.BlogService/BatchGetBlogs",
+}
+
+// caller returns the verified identity of the caller. ok is false when
+// authentication is turned off, in which case every call is allowed.
+func (s *server) caller(ctx context.Context) (id auth.Identity, ok bool, err error) {
+	if !s.authEnabled {
+		return auth.Identity{}, false, nil
+	}
+	id, found := auth.FromContext(ctx)
+	if !found {
+		return auth.Identity{}, true, status.Errorf(codes.Unauthenticated, "Missing bearer token")
+	}
+	return id, true, nil
+}
+
+// authorAllowed checks that the caller may write blogs as authorID.
+func (s *server) authorAllowed(ctx context.Context, authorID string) error {
+	id, ok, err := s.caller(ctx)
+	if err != nil || !ok {
+		return err
+	}
+	if id.IsAdmin() || id.Subject == authorID {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "Cannot act on blogs of author %q", authorID)
+}
+
+// adminAllowed checks that the caller is an admin.
+func (s *server) adminAllowed(ctx context.Context) error {
+	id, ok, err := s.caller(ctx)
+	if err != nil || !ok {
+		return err
+	}
+	if !id.IsAdmin() {
+		return status.Errorf(codes.PermissionDenied, "Only admins can do this")
+	}
+	return nil
+}
+
+// ownerAllowed checks that the caller may modify the live blog with the
+// given ID. It returns the version that was checked, so the following
+// write can be pinned to it and fail if the blog changed hands since.
+// version is the version the client expects, if any.
+func (s *server) ownerAllowed(ctx context.Context, oid primitive.ObjectID, version int64) (int64, error) {
+	if !s.authEnabled {
+		return version, nil
+	}
+	current, err := s.store.Get(ctx, oid)
+	if err == errNotFound {
+		return 0, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Cannot find blog with specified ID: %v", err),
+		)
+	}
+	if err != nil {
+		return 0, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	if err := s.authorAllowed(ctx, current.AuthorID); err != nil {
+		return 0, err
+	}
+	if version != 0 && version != current.Version {
+		return 0, status.Errorf(
+			codes.Aborted,
+			fmt.Sprintf("Blog was modified concurrently: %v", errVersionMismatch),
+		)
+	}
+	return current.Version, nil
+}
@@ -0,0 +1,83 @@
+package blogsvc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func as(subject string, roles ...string) context.Context {
+	return auth.NewContext(context.Background(), auth.Identity{Subject: subject, Roles: roles})
+}
+
+func TestCreateBlogAuthor(t *testing.T) {
+	s := newTestServer()
+	s.authEnabled = true
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		author string
+		want   string
+	}{
+		{"own name", as("ann"), "ann", "ann"},
+		{"other author", as("ann"), "bob", "ann"},
+		{"no author", as("ann"), "", "ann"},
+		{"admin for another author", as("root", auth.AdminRole), "bob", "bob"},
+		{"admin without author", as("root", auth.AdminRole), "", "root"},
+	}
+	for _, tt := range tests {
+		if got := mustCreate(t, tt.ctx, s, tt.author, "Title", ""); got.GetAutherId() != tt.want {
+			t.Errorf("%s: created as %q, want %q", tt.name, got.GetAutherId(), tt.want)
+		}
+	}
+
+	_, err := s.CreateBlog(context.Background(), &blogpb.CreateBlogRequest{
+		Blog: &blogpb.Blog{AutherId: "ann", Title: "Title"},
+	})
+	wantCode(t, err, codes.Unauthenticated)
+}
+
+func TestOwnerAllowed(t *testing.T) {
+	s := newTestServer()
+	s.authEnabled = true
+	blog := mustCreate(t, as("ann"), s, "ann", "Title", "Content")
+	id := blog.GetId()
+	update := func(ctx context.Context, b *blogpb.Blog, paths ...string) error {
+		b.Id = id
+		_, err := s.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{Blog: b, UpdateMask: &fieldmaskpb.FieldMask{Paths: paths}})
+		return err
+	}
+
+	wantCode(t, update(as("bob"), &blogpb.Blog{Title: "Taken"}, "title"), codes.PermissionDenied)
+	_, err := s.DeleteBlog(as("bob"), &blogpb.DeleteBlogRequest{BlogId: id})
+	wantCode(t, err, codes.PermissionDenied)
+	wantCode(t, update(context.Background(), &blogpb.Blog{Title: "Anonymous"}, "title"), codes.Unauthenticated)
+	// The owner cannot give the blog away, but an admin can.
+	wantCode(t, update(as("ann"), &blogpb.Blog{AutherId: "bob"}, "auther_id"), codes.PermissionDenied)
+
+	read, err := s.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{BlogId: id})
+	if err != nil {
+		t.Fatalf("ReadBlog: %v", err)
+	}
+	if got := read.GetBlog(); got.GetTitle() != "Title" || got.GetAutherId() != "ann" || got.GetVersion() != 1 {
+		t.Fatalf("blog = %v after rejected writes, want it unchanged", got)
+	}
+
+	if err := update(as("ann"), &blogpb.Blog{Title: "Edited"}, "title"); err != nil {
+		t.Errorf("owner updating: %v", err)
+	}
+	if err := update(as("root", auth.AdminRole), &blogpb.Blog{AutherId: "bob"}, "auther_id"); err != nil {
+		t.Errorf("admin moving the blog: %v", err)
+	}
+	// The blog is bob's now.
+	_, err = s.DeleteBlog(as("ann"), &blogpb.DeleteBlogRequest{BlogId: id})
+	wantCode(t, err, codes.PermissionDenied)
+	if _, err := s.DeleteBlog(as("bob"), &blogpb.DeleteBlogRequest{BlogId: id}); err != nil {
+		t.Errorf("new owner deleting: %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package blogsvc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchSize caps the number of items in one batch call.
+const maxBatchSize = 500
+
+func checkBatchSize(n int) error {
+	if n > maxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "Batch has %d items, at most %d are allowed", n, maxBatchSize)
+	}
+	return nil
+}
+
+// failed is the result of a batch item that could not be processed.
+func failed(code codes.Code, message string) *blogpb.BlogResult {
+	return &blogpb.BlogResult{Code: int32(code), Message: message}
+}
+
+// parseIDs parses blog IDs, leaving a failed result for each that is not
+// a valid ID.
+func parseIDs(ids []string) ([]primitive.ObjectID, []*blogpb.BlogResult) {
+	oids := make([]primitive.ObjectID, len(ids))
+	results := make([]*blogpb.BlogResult, len(ids))
+	for i, id := range ids {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			results[i] = failed(codes.InvalidArgument, "Cannot parse ID")
+			continue
+		}
+		oids[i] = oid
+	}
+	return oids, results
+}
+
+func (s *server) BatchCreateBlogs(ctx context.Context, req *blogpb.BatchCreateBlogsRequest) (*blogpb.BatchCreateBlogsResponse, error) {
+	if err := checkBatchSize(len(req.GetRequests())); err != nil {
+		return nil, err
+	}
+	results := make([]*blogpb.BlogResult, len(req.GetRequests()))
+	var items []*blogItem
+	var indexes []int
+	var violations []*errdetails.BadRequest_FieldViolation
+	for i, r := range req.GetRequests() {
+		data, err := s.newItem(ctx, r.GetBlog())
+		if err != nil {
+			return nil, err
+		}
+		itemViolations := fieldViolations(
+			&blogpb.CreateBlogRequest{Blog: data.toBlog()},
+			fmt.Sprintf("requests[%d].", i),
+			nil,
+		)
+		if len(itemViolations) > 0 {
+			violations = append(violations, itemViolations...)
+			results[i] = failed(codes.InvalidArgument, status.Convert(badRequest(itemViolations)).Message())
+			continue
+		}
+		items = append(items, data)
+		indexes = append(indexes, i)
+	}
+	if req.GetAllOrNothing() && len(violations) > 0 {
+		return nil, badRequest(violations)
+	}
+
+	created, err := s.store.CreateMany(ctx, items)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	for j, data := range created {
+		s.events.publish(blogpb.BlogEvent_CREATED, data)
+		results[indexes[j]] = &blogpb.BlogResult{Blog: data.toBlog()}
+	}
+	return &blogpb.BatchCreateBlogsResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *server) BatchGetBlogs(ctx context.Context, req *blogpb.BatchGetBlogsRequest) (*blogpb.BatchGetBlogsResponse, error) {
+	if err := checkBatchSize(len(req.GetBlogIds())); err != nil {
+		return nil, err
+	}
+	oids, results := parseIDs(req.GetBlogIds())
+	found, err := s.store.GetMany(ctx, oids)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	for i, oid := range oids {
+		if results[i] != nil {
+			continue
+		}
+		if data, ok := found[oid]; ok {
+			results[i] = &blogpb.BlogResult{Blog: data.toBlog()}
+		} else {
+			results[i] = failed(codes.NotFound, fmt.Sprintf("Cannot find blog with specified ID: %v", errNotFound))
+		}
+	}
+	return &blogpb.BatchGetBlogsResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *server) BatchDeleteBlogs(ctx context.Context, req *blogpb.BatchDeleteBlogsRequest) (*blogpb.BatchDeleteBlogsResponse, error) {
+	if err := checkBatchSize(len(req.GetBlogIds())); err != nil {
+		return nil, err
+	}
+	oids, results := parseIDs(req.GetBlogIds())
+	q := deleteQuery{Purge: req.GetPurge(), IncludeDeleted: true}
+	id, ok, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ok && !id.IsAdmin() {
+		// Ownership is part of the store's filter, so a blog that changes
+		// hands midway is not deleted. Soft-deleted blogs stay hidden.
+		q.AuthorID = id.Subject
+		q.IncludeDeleted = false
+	}
+	for i, oid := range oids {
+		if results[i] == nil {
+			q.IDs = append(q.IDs, oid)
+		}
+	}
+
+	deleted, err := s.store.DeleteMany(ctx, q)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	var missing []primitive.ObjectID
+	for _, oid := range q.IDs {
+		if _, ok := deleted[oid]; !ok {
+			missing = append(missing, oid)
+		}
+	}
+	// Blogs that were left alone but still exist belong to someone else.
+	others := map[primitive.ObjectID]*blogItem{}
+	if len(missing) > 0 && q.AuthorID != "" {
+		if others, err = s.store.GetMany(ctx, missing); err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				fmt.Sprintf("Internal error: %v", err),
+			)
+		}
+	}
+
+	for _, data := range deleted {
+		s.events.publish(blogpb.BlogEvent_DELETED, data)
+	}
+	for i, oid := range oids {
+		if results[i] != nil {
+			continue
+		}
+		if data, ok := deleted[oid]; ok {
+			results[i] = &blogpb.BlogResult{Blog: data.toBlog()}
+		} else if data, ok := others[oid]; ok {
+			results[i] = failed(codes.PermissionDenied, fmt.Sprintf("Cannot act on blogs of author %q", data.AuthorID))
+		} else {
+			results[i] = failed(codes.NotFound, fmt.Sprintf("Cannot find blog with specified ID: %v", errNotFound))
+		}
+	}
+	return &blogpb.BatchDeleteBlogsResponse{
+		Results: results,
+	}, nil
+}
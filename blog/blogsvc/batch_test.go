@@ -0,0 +1,128 @@
+package blogsvc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resultCodes returns the code of every batch result, and the titles of
+// the blogs of the successful ones.
+func resultCodes(results []*blogpb.BlogResult) ([]codes.Code, []string) {
+	var got []codes.Code
+	var titles []string
+	for _, r := range results {
+		got = append(got, codes.Code(r.GetCode()))
+		if r.GetBlog() != nil {
+			titles = append(titles, r.GetBlog().GetTitle())
+		}
+	}
+	return got, titles
+}
+
+func TestBatchGetBlogs(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer()
+	a := mustCreate(t, ctx, s, "ann", "a", "")
+	b := mustCreate(t, ctx, s, "ann", "b", "")
+
+	res, err := s.BatchGetBlogs(ctx, &blogpb.BatchGetBlogsRequest{
+		BlogIds: []string{b.GetId(), "not-an-id", primitive.NewObjectID().Hex(), a.GetId(), b.GetId()},
+	})
+	if err != nil {
+		t.Fatalf("BatchGetBlogs: %v", err)
+	}
+	got, titles := resultCodes(res.GetResults())
+	want := []codes.Code{codes.OK, codes.InvalidArgument, codes.NotFound, codes.OK, codes.OK}
+	if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(titles, []string{"b", "a", "b"}) {
+		t.Errorf("got codes %v with blogs %v, want %v with b, a, b", got, titles, want)
+	}
+
+	_, err = s.BatchGetBlogs(ctx, &blogpb.BatchGetBlogsRequest{BlogIds: make([]string, maxBatchSize+1)})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestBatchCreateBlogs(t *testing.T) {
+	ctx := context.Background()
+	requests := []*blogpb.CreateBlogRequest{
+		{Blog: &blogpb.Blog{AutherId: "ann", Title: "first"}},
+		{Blog: &blogpb.Blog{AutherId: "ann"}},
+		{Blog: &blogpb.Blog{AutherId: "ann", Title: "third"}},
+	}
+
+	s := newTestServer()
+	_, err := s.BatchCreateBlogs(ctx, &blogpb.BatchCreateBlogsRequest{Requests: requests, AllOrNothing: true})
+	wantCode(t, err, codes.InvalidArgument)
+	var fields []string
+	for _, detail := range status.Convert(err).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				fields = append(fields, v.GetField())
+			}
+		}
+	}
+	if !reflect.DeepEqual(fields, []string{"requests[1].blog.title"}) {
+		t.Errorf("violations of %v, want requests[1].blog.title", fields)
+	}
+	if ids, _ := listIDs(t, ctx, s, &blogpb.ListBlogRequest{}); len(ids) != 0 {
+		t.Errorf("all-or-nothing batch with an invalid item created %v", ids)
+	}
+
+	res, err := s.BatchCreateBlogs(ctx, &blogpb.BatchCreateBlogsRequest{Requests: requests})
+	if err != nil {
+		t.Fatalf("BatchCreateBlogs: %v", err)
+	}
+	got, titles := resultCodes(res.GetResults())
+	if want := []codes.Code{codes.OK, codes.InvalidArgument, codes.OK}; !reflect.DeepEqual(got, want) || !reflect.DeepEqual(titles, []string{"first", "third"}) {
+		t.Errorf("got codes %v with blogs %v, want %v with first and third", got, titles, want)
+	}
+	if ids, _ := listIDs(t, ctx, s, &blogpb.ListBlogRequest{}); len(ids) != 2 {
+		t.Errorf("listed %d blogs, want the 2 valid ones", len(ids))
+	}
+}
+
+func TestBatchDeleteBlogs(t *testing.T) {
+	s := newTestServer()
+	s.authEnabled = true
+	own := mustCreate(t, as("ann"), s, "ann", "own", "")
+	other := mustCreate(t, as("bob"), s, "bob", "other", "")
+
+	res, err := s.BatchDeleteBlogs(as("ann"), &blogpb.BatchDeleteBlogsRequest{
+		BlogIds: []string{other.GetId(), own.GetId(), primitive.NewObjectID().Hex(), "not-an-id"},
+	})
+	if err != nil {
+		t.Fatalf("BatchDeleteBlogs: %v", err)
+	}
+	got, _ := resultCodes(res.GetResults())
+	if want := []codes.Code{codes.PermissionDenied, codes.OK, codes.NotFound, codes.InvalidArgument}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got codes %v, want %v", got, want)
+	}
+	if res.GetResults()[1].GetBlog().GetDeletedAt() == nil {
+		t.Errorf("deleted blog has no deletion time")
+	}
+	if _, err := s.ReadBlog(as("bob"), &blogpb.ReadBlogRequest{BlogId: other.GetId()}); err != nil {
+		t.Errorf("blog of another author was deleted: %v", err)
+	}
+
+	// Admins delete anyone's blogs, and purge soft-deleted ones.
+	res, err = s.BatchDeleteBlogs(as("root", auth.AdminRole), &blogpb.BatchDeleteBlogsRequest{
+		BlogIds: []string{other.GetId(), own.GetId()},
+		Purge:   true,
+	})
+	if err != nil {
+		t.Fatalf("BatchDeleteBlogs as admin: %v", err)
+	}
+	if got, _ := resultCodes(res.GetResults()); !reflect.DeepEqual(got, []codes.Code{codes.OK, codes.OK}) {
+		t.Errorf("admin got codes %v, want both deleted", got)
+	}
+	if ids, _ := listIDs(t, as("root", auth.AdminRole), s, &blogpb.ListBlogRequest{IncludeDeleted: true}); len(ids) != 0 {
+		t.Errorf("listed %v after purging, want nothing", ids)
+	}
+}
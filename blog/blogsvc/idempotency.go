@@ -0,0 +1,198 @@
+package blogsvc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"github.com/shivkumar123g/grpc_go_course/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// IdempotencyKeyHeader is the metadata key of the idempotency key a
+// client may send with a call that changes blogs. The first successful
+// call with a key is applied; calls repeating it with the same request
+// get its response back until the key expires.
+const IdempotencyKeyHeader = "idempotency-key"
+
+// ReplayedHeader is set to "true" in the response header of a call
+// answered with the stored response of an earlier one.
+const ReplayedHeader = "idempotency-replayed"
+
+// maxKeyLength caps the length of idempotency keys.
+const maxKeyLength = 128
+
+// keyLease is how long a call holds its key before finishing. It bounds
+// how long a key stays taken by a call that never finishes, say because
+// the server stopped halfway.
+const keyLease = time.Minute
+
+// keysCollectionSuffix names the Mongo collection of idempotency records
+// after the blog collection.
+const keysCollectionSuffix = "_idempotency_keys"
+
+// idempotentMethods are the calls that honour idempotency keys.
+var idempotentMethods = map[string]bool{
+	"/blog.BlogService/CreateBlog":       true,
+	"/blog.BlogService/UpdateBlog":       true,
+	"/blog.BlogService/DeleteBlog":       true,
+	"/blog.BlogService/UndeleteBlog":     true,
+	"/blog.BlogService/BatchCreateBlogs": true,
+	"/blog.BlogService/BatchDeleteBlogs": true,
+}
+
+// UnaryServerInterceptor applies the BlogService calls that carry an
+// idempotency key once per key and caller, replaying the stored response
+// to repeated calls. Failed calls, panicking ones too, are not stored, so
+// they may be retried with the same key. It must follow the auth
+// interceptors, which identify the caller.
+func (svc *Service) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if svc.idempotencyTTL <= 0 || !idempotentMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(IdempotencyKeyHeader)
+		if len(values) == 0 || values[0] == "" {
+			return handler(ctx, req)
+		}
+		key := values[0]
+		if len(key) > maxKeyLength {
+			return nil, status.Errorf(codes.InvalidArgument, "Idempotency key is longer than %d characters", maxKeyLength)
+		}
+		m, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		hash, err := requestHash(info.FullMethod, m)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				fmt.Sprintf("Internal error: %v", err),
+			)
+		}
+
+		store := svc.server.store
+		rec := &idempotencyRecord{
+			ID:          recordID(ctx, key),
+			RequestHash: hash,
+			ExpiresAt:   now().Add(keyLease),
+		}
+		stored, err := store.ReserveKey(ctx, rec)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				fmt.Sprintf("Internal error: %v", err),
+			)
+		}
+		if stored != nil {
+			return replay(ctx, stored, hash)
+		}
+
+		// The key is released or completed even if the caller has gone
+		// away, as the call itself may have gone through.
+		bg := context.WithoutCancel(ctx)
+		release := func() {
+			if err := store.ReleaseKey(bg, rec.ID); err != nil {
+				logging.FromContext(ctx).Warn("Cannot release idempotency key", "error", err)
+			}
+		}
+		// A panicking handler fails the call too, once the recovery
+		// interceptor outside this one is done with it.
+		defer func() {
+			if p := recover(); p != nil {
+				release()
+				panic(p)
+			}
+		}()
+		resp, err := handler(ctx, req)
+		if err != nil {
+			release()
+			return resp, err
+		}
+		if err := complete(bg, store, rec.ID, resp, svc.idempotencyTTL); err != nil {
+			// The call went through, so it succeeds anyway; a repeat is
+			// turned away until the lease on the key runs out.
+			logging.FromContext(ctx).Warn("Cannot store idempotent response", "error", err)
+		}
+		return resp, nil
+	}
+}
+
+// complete stores resp as the response of the call holding the record
+// with the given ID, to be replayed for ttl.
+func complete(ctx context.Context, store BlogStore, id string, resp interface{}, ttl time.Duration) error {
+	m, ok := resp.(proto.Message)
+	if !ok {
+		return fmt.Errorf("response %T is not a proto message", resp)
+	}
+	wrapped, err := anypb.New(m)
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(wrapped)
+	if err != nil {
+		return err
+	}
+	return store.CompleteKey(ctx, id, data, now().Add(ttl))
+}
+
+// replay answers a call whose key is already taken by stored.
+func replay(ctx context.Context, stored *idempotencyRecord, hash []byte) (interface{}, error) {
+	if !bytes.Equal(stored.RequestHash, hash) {
+		return nil, status.Errorf(codes.InvalidArgument, "Idempotency key was already used with a different request")
+	}
+	if !stored.Done {
+		return nil, status.Errorf(codes.Aborted, "A call with the same idempotency key is still in progress")
+	}
+	wrapped := &anypb.Any{}
+	if err := proto.Unmarshal(stored.Response, wrapped); err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	resp, err := wrapped.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(ReplayedHeader, "true"))
+	logging.FromContext(ctx).Info("Replayed response of idempotent call")
+	return resp, nil
+}
+
+// recordID scopes key to the caller, so that callers cannot see each
+// other's responses by guessing keys.
+func recordID(ctx context.Context, key string) string {
+	subject := ""
+	if id, ok := auth.FromContext(ctx); ok {
+		subject = id.Subject
+	}
+	sum := sha256.Sum256([]byte(subject + "\x00" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestHash identifies a call to method with request m. Deterministic
+// marshalling makes equal requests hash the same.
+func requestHash(method string, m proto.Message) ([]byte, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	h.Write([]byte(method + "\x00"))
+	h.Write(data)
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,163 @@
+package blogsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// headerStream records the response header a unary call sets.
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// idempotentCaller makes CreateBlog calls through the idempotency
+// interceptor of a Service on the memory store.
+type idempotentCaller struct {
+	svc *Service
+	// handler stands in for CreateBlog when set.
+	handler grpc.UnaryHandler
+	calls   int
+}
+
+func newIdempotentCaller(ttl time.Duration) *idempotentCaller {
+	return &idempotentCaller{svc: &Service{server: newTestServer(), idempotencyTTL: ttl}}
+}
+
+// create calls CreateBlog as subject with key, and returns the blog and
+// whether its response was replayed.
+func (c *idempotentCaller) create(subject, key, title string) (*blogpb.Blog, bool, error) {
+	ctx := auth.NewContext(context.Background(), auth.Identity{Subject: subject})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(IdempotencyKeyHeader, key))
+	stream := &headerStream{}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+	req := &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{AutherId: subject, Title: title}}
+	info := &grpc.UnaryServerInfo{FullMethod: "/blog.BlogService/CreateBlog"}
+	resp, err := c.svc.UnaryServerInterceptor()(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		c.calls++
+		if c.handler != nil {
+			return c.handler(ctx, req)
+		}
+		return c.svc.server.CreateBlog(ctx, req.(*blogpb.CreateBlogRequest))
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	replayed := len(stream.header.Get(ReplayedHeader)) == 1 && stream.header.Get(ReplayedHeader)[0] == "true"
+	return resp.(*blogpb.CreateBlogResponse).GetBlog(), replayed, nil
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+	c := newIdempotentCaller(time.Hour)
+	first, replayed, err := c.create("ann", "k1", "Hello")
+	if err != nil || replayed {
+		t.Fatalf("first call: replayed %v, %v", replayed, err)
+	}
+	again, replayed, err := c.create("ann", "k1", "Hello")
+	if err != nil {
+		t.Fatalf("repeated call: %v", err)
+	}
+	if !replayed || again.GetId() != first.GetId() || c.calls != 1 {
+		t.Errorf("repeated call got blog %s, replayed %v after %d calls; want blog %s replayed after 1",
+			again.GetId(), replayed, c.calls, first.GetId())
+	}
+
+	_, _, err = c.create("ann", "k1", "Other title")
+	wantCode(t, err, codes.InvalidArgument)
+
+	// Another key, or the same key of another caller, makes a new blog.
+	for _, who := range []struct{ subject, key string }{{"ann", "k2"}, {"bob", "k1"}} {
+		blog, replayed, err := c.create(who.subject, who.key, "Hello")
+		if err != nil || replayed || blog.GetId() == first.GetId() {
+			t.Errorf("%s with key %s: got blog %s, replayed %v, %v; want a new blog", who.subject, who.key, blog.GetId(), replayed, err)
+		}
+	}
+	if c.calls != 3 {
+		t.Errorf("handler ran %d times, want 3", c.calls)
+	}
+}
+
+func TestIdempotencyInProgress(t *testing.T) {
+	c := newIdempotentCaller(time.Hour)
+	c.handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+		c.handler = nil
+		_, _, err := c.create("ann", "k1", "Hello")
+		wantCode(t, err, codes.Aborted)
+		return c.svc.server.CreateBlog(ctx, req.(*blogpb.CreateBlogRequest))
+	}
+	if _, _, err := c.create("ann", "k1", "Hello"); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if _, replayed, _ := c.create("ann", "k1", "Hello"); !replayed {
+		t.Errorf("call after the first finished was not replayed")
+	}
+}
+
+func TestIdempotencyFailedCall(t *testing.T) {
+	c := newIdempotentCaller(time.Hour)
+	c.handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	_, _, err := c.create("ann", "k1", "Hello")
+	wantCode(t, err, codes.Unavailable)
+
+	// A panic fails the call just the same.
+	c.handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	}
+	func() {
+		defer func() {
+			if p := recover(); p != "boom" {
+				t.Errorf("recovered %v, want the handler's panic", p)
+			}
+		}()
+		c.create("ann", "k1", "Hello")
+	}()
+
+	c.handler = nil
+	blog, replayed, err := c.create("ann", "k1", "Hello")
+	if err != nil || replayed || blog.GetTitle() != "Hello" {
+		t.Errorf("retry after failures: got %v, replayed %v, %v; want the blog created", blog, replayed, err)
+	}
+	if c.calls != 3 {
+		t.Errorf("handler ran %d times, want 3", c.calls)
+	}
+}
+
+func TestIdempotencyExpiry(t *testing.T) {
+	c := newIdempotentCaller(20 * time.Millisecond)
+	first, _, err := c.create("ann", "k1", "Hello")
+	if err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	again, replayed, err := c.create("ann", "k1", "Hello")
+	if err != nil || replayed || again.GetId() == first.GetId() {
+		t.Errorf("call after the key expired: got blog %s, replayed %v, %v; want a new blog", again.GetId(), replayed, err)
+	}
+}
+
+func TestIdempotencyWithoutKey(t *testing.T) {
+	c := newIdempotentCaller(time.Hour)
+	for i := 0; i < 2; i++ {
+		if _, replayed, err := c.create("ann", "", "Hello"); err != nil || replayed {
+			t.Fatalf("call %d without a key: replayed %v, %v", i+1, replayed, err)
+		}
+	}
+	if c.calls != 2 {
+		t.Errorf("handler ran %d times, want 2", c.calls)
+	}
+}
@@ -0,0 +1,289 @@
+package blogsvc
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryStore keeps blogs in process memory. It is safe for concurrent use
+// and is meant for running the server without a database.
+type memoryStore struct {
+	mu    sync.RWMutex
+	blogs map[primitive.ObjectID]*blogItem
+	keys  map[string]*idempotencyRecord
+	// nextSweep is when expired idempotency records are dropped next.
+	nextSweep time.Time
+}
+
+// sweepInterval is how often the memory store drops expired idempotency
+// records.
+const sweepInterval = time.Minute
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		blogs: make(map[primitive.ObjectID]*blogItem),
+		keys:  make(map[string]*idempotencyRecord),
+	}
+}
+
+func (s *memoryStore) Create(ctx context.Context, item *blogItem) (*blogItem, error) {
+	created := *item
+	created.ID = primitive.NewObjectID()
+	created.Version = 1
+	created.CreatedAt = now()
+	created.UpdatedAt = created.CreatedAt
+	created.DeletedAt = nil
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := created
+	s.blogs[created.ID] = &stored
+	return &created, nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, err := s.find(id, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	found := *data
+	return &found, nil
+}
+
+func (s *memoryStore) Update(ctx context.Context, item *blogItem, fields []string) (*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := s.find(item.ID, item.Version, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		data.setField(field, item.field(field))
+	}
+	data.touch()
+	updated := *data
+	return &updated, nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := s.find(id, version, false)
+	if err != nil {
+		return nil, err
+	}
+	data.touch()
+	deletedAt := data.UpdatedAt
+	data.DeletedAt = &deletedAt
+	deleted := *data
+	return &deleted, nil
+}
+
+func (s *memoryStore) Undelete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := s.find(id, version, true)
+	if err != nil {
+		return nil, err
+	}
+	if data.DeletedAt != nil {
+		data.DeletedAt = nil
+		data.touch()
+	}
+	restored := *data
+	return &restored, nil
+}
+
+func (s *memoryStore) Purge(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := s.find(id, version, true)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.blogs, id)
+	return data, nil
+}
+
+// find returns the stored blog with the given ID, checking a non-zero
+// version the way the BlogStore methods do. The caller must hold s.mu.
+func (s *memoryStore) find(id primitive.ObjectID, version int64, includeDeleted bool) (*blogItem, error) {
+	data, ok := s.blogs[id]
+	if !ok || (data.DeletedAt != nil && !includeDeleted) {
+		return nil, errNotFound
+	}
+	if version != 0 && version != data.Version {
+		return nil, errVersionMismatch
+	}
+	return data, nil
+}
+
+func (s *memoryStore) List(ctx context.Context, q listQuery, fn func(*blogItem) error) error {
+	// Copy under the lock so fn can call back into the store.
+	s.mu.RLock()
+	items := make([]blogItem, 0, len(s.blogs))
+	for _, data := range s.blogs {
+		if q.AuthorID != "" && data.AuthorID != q.AuthorID {
+			continue
+		}
+		if data.DeletedAt != nil && !q.IncludeDeleted {
+			continue
+		}
+		if q.After != nil && !q.before(q.After, data) {
+			continue
+		}
+		items = append(items, *data)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		return q.before(&items[i], &items[j])
+	})
+	if q.Limit > 0 && len(items) > q.Limit {
+		items = items[:q.Limit]
+	}
+	for i := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(&items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) Search(ctx context.Context, q searchQuery) ([]searchHit, error) {
+	terms := queryTerms(q.Text)
+	var hits []searchHit
+	s.mu.RLock()
+	for _, data := range s.blogs {
+		if data.DeletedAt != nil || (q.AuthorID != "" && data.AuthorID != q.AuthorID) {
+			continue
+		}
+		if score := scoreBlog(data, terms); score > 0 {
+			found := *data
+			hits = append(hits, searchHit{Item: &found, Score: score})
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return bytes.Compare(hits[i].Item.ID[:], hits[j].Item.ID[:]) < 0
+	})
+	if q.Limit > 0 && len(hits) > q.Limit {
+		hits = hits[:q.Limit]
+	}
+	return hits, nil
+}
+
+func (s *memoryStore) CreateMany(ctx context.Context, items []*blogItem) ([]*blogItem, error) {
+	created := make([]*blogItem, 0, len(items))
+	for _, item := range items {
+		data, err := s.Create(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, data)
+	}
+	return created, nil
+}
+
+func (s *memoryStore) GetMany(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*blogItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	found := make(map[primitive.ObjectID]*blogItem)
+	for _, id := range ids {
+		if data, err := s.find(id, 0, false); err == nil {
+			item := *data
+			found[id] = &item
+		}
+	}
+	return found, nil
+}
+
+func (s *memoryStore) DeleteMany(ctx context.Context, q deleteQuery) (map[primitive.ObjectID]*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleted := make(map[primitive.ObjectID]*blogItem)
+	for _, id := range q.IDs {
+		data, err := s.find(id, 0, q.Purge && q.IncludeDeleted)
+		if err != nil || (q.AuthorID != "" && data.AuthorID != q.AuthorID) {
+			continue
+		}
+		if q.Purge {
+			delete(s.blogs, id)
+		} else {
+			data.touch()
+			deletedAt := data.UpdatedAt
+			data.DeletedAt = &deletedAt
+		}
+		item := *data
+		deleted[id] = &item
+	}
+	return deleted, nil
+}
+
+func (s *memoryStore) ReserveKey(ctx context.Context, rec *idempotencyRecord) (*idempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := now()
+	if t.After(s.nextSweep) {
+		for id, stored := range s.keys {
+			if !stored.ExpiresAt.After(t) {
+				delete(s.keys, id)
+			}
+		}
+		s.nextSweep = t.Add(sweepInterval)
+	}
+	if stored, ok := s.keys[rec.ID]; ok && stored.ExpiresAt.After(t) {
+		found := *stored
+		return &found, nil
+	}
+	reserved := *rec
+	s.keys[rec.ID] = &reserved
+	return nil, nil
+}
+
+func (s *memoryStore) CompleteKey(ctx context.Context, id string, response []byte, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stored, ok := s.keys[id]; ok {
+		stored.Done = true
+		stored.Response = response
+		stored.ExpiresAt = expiresAt
+	}
+	return nil
+}
+
+func (s *memoryStore) ReleaseKey(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stored, ok := s.keys[id]; ok && !stored.Done {
+		delete(s.keys, id)
+	}
+	return nil
+}
+
+// before reports whether a is listed before b under q's ordering.
+func (q listQuery) before(a, b *blogItem) bool {
+	if q.Descending {
+		a, b = b, a
+	}
+	if q.SortBy != "" {
+		if av, bv := a.field(q.SortBy), b.field(q.SortBy); av != bv {
+			return av < bv
+		}
+	}
+	return bytes.Compare(a.ID[:], b.ID[:]) < 0
+}
@@ -0,0 +1,434 @@
+package blogsvc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoStore keeps blogs in a MongoDB collection, and idempotency records
+// in another one next to it.
+type mongoStore struct {
+	collection *mongo.Collection
+	keys       *mongo.Collection
+}
+
+func newMongoStore(collection, keys *mongo.Collection) *mongoStore {
+	return &mongoStore{collection: collection, keys: keys}
+}
+
+func (s *mongoStore) Create(ctx context.Context, item *blogItem) (*blogItem, error) {
+	created := *item
+	created.Version = 1
+	created.CreatedAt = now()
+	created.UpdatedAt = created.CreatedAt
+	created.DeletedAt = nil
+	res, err := s.collection.InsertOne(ctx, &created)
+	if err != nil {
+		return nil, err
+	}
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, errors.New("cannot convert to OID")
+	}
+	created.ID = oid
+	return &created, nil
+}
+
+func (s *mongoStore) Get(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	return s.find(ctx, id, false)
+}
+
+func (s *mongoStore) Update(ctx context.Context, item *blogItem, fields []string) (*blogItem, error) {
+	set := bson.D{primitive.E{Key: "updated_at", Value: now()}}
+	for _, field := range fields {
+		set = append(set, primitive.E{Key: field, Value: item.field(field)})
+	}
+	data := bson.D{
+		primitive.E{Key: "$set", Value: set},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	return s.modify(ctx, item.ID, item.Version, data)
+}
+
+func (s *mongoStore) Delete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	t := now()
+	data := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "updated_at", Value: t},
+			primitive.E{Key: "deleted_at", Value: t},
+		}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	return s.modify(ctx, id, version, data)
+}
+
+func (s *mongoStore) Undelete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	filter := append(
+		blogFilter(id, version, true),
+		primitive.E{Key: "deleted_at", Value: bson.D{primitive.E{Key: "$ne", Value: nil}}},
+	)
+	data := bson.D{
+		primitive.E{Key: "$unset", Value: bson.D{primitive.E{Key: "deleted_at", Value: ""}}},
+		primitive.E{Key: "$set", Value: bson.D{primitive.E{Key: "updated_at", Value: now()}}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	restored := &blogItem{}
+	err := s.collection.FindOneAndUpdate(ctx, filter, data, opts).Decode(restored)
+	if err != mongo.ErrNoDocuments {
+		if err != nil {
+			return nil, err
+		}
+		return restored, nil
+	}
+	// The filter also misses blogs that are not deleted at all, which are
+	// returned as they are.
+	found, err := s.find(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if found.DeletedAt != nil || (version != 0 && version != found.Version) {
+		return nil, errVersionMismatch
+	}
+	return found, nil
+}
+
+func (s *mongoStore) Purge(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	purged := &blogItem{}
+	err := s.collection.FindOneAndDelete(ctx, blogFilter(id, version, true)).Decode(purged)
+	if err == mongo.ErrNoDocuments {
+		return nil, s.missReason(ctx, id, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
+
+// find returns the blog with the given ID, or errNotFound.
+func (s *mongoStore) find(ctx context.Context, id primitive.ObjectID, includeDeleted bool) (*blogItem, error) {
+	data := &blogItem{}
+	if err := s.collection.FindOne(ctx, blogFilter(id, 0, includeDeleted)).Decode(data); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// modify applies update to the live blog with the given ID and version and
+// returns it as stored afterwards.
+func (s *mongoStore) modify(ctx context.Context, id primitive.ObjectID, version int64, update bson.D) (*blogItem, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	updated := &blogItem{}
+	err := s.collection.FindOneAndUpdate(ctx, blogFilter(id, version, false), update, opts).Decode(updated)
+	if err == mongo.ErrNoDocuments {
+		return nil, s.missReason(ctx, id, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// blogFilter matches the blog with the given ID, skipping soft-deleted
+// blogs unless includeDeleted is set. A non-zero version must match as
+// well; putting it in the filter makes the check and the write a single
+// atomic operation.
+func blogFilter(id primitive.ObjectID, version int64, includeDeleted bool) bson.D {
+	filter := bson.D{primitive.E{Key: "_id", Value: id}}
+	if version != 0 {
+		filter = append(filter, primitive.E{Key: "version", Value: version})
+	}
+	if !includeDeleted {
+		filter = append(filter, primitive.E{Key: "deleted_at", Value: nil})
+	}
+	return filter
+}
+
+// missReason tells why a versioned write matched nothing: errNotFound when
+// the blog is gone, errVersionMismatch when it exists at another version.
+func (s *mongoStore) missReason(ctx context.Context, id primitive.ObjectID, includeDeleted bool) error {
+	if _, err := s.find(ctx, id, includeDeleted); err != nil {
+		return err
+	}
+	return errVersionMismatch
+}
+
+func (s *mongoStore) List(ctx context.Context, q listQuery, fn func(*blogItem) error) error {
+	dir := 1
+	if q.Descending {
+		dir = -1
+	}
+	sort := bson.D{}
+	if q.SortBy != "" {
+		sort = append(sort, primitive.E{Key: q.SortBy, Value: dir})
+	}
+	sort = append(sort, primitive.E{Key: "_id", Value: dir})
+	opts := options.Find().SetSort(sort)
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit))
+	}
+	cur, err := s.collection.Find(ctx, listFilter(q), opts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		data := &blogItem{}
+		if err := cur.Decode(data); err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+func (s *mongoStore) Search(ctx context.Context, q searchQuery) ([]searchHit, error) {
+	filter := bson.D{
+		primitive.E{Key: "$text", Value: bson.D{primitive.E{Key: "$search", Value: q.Text}}},
+		primitive.E{Key: "deleted_at", Value: nil},
+	}
+	if q.AuthorID != "" {
+		filter = append(filter, primitive.E{Key: "author_id", Value: q.AuthorID})
+	}
+	score := bson.D{primitive.E{Key: "score", Value: bson.D{primitive.E{Key: "$meta", Value: "textScore"}}}}
+	opts := options.Find().SetProjection(score).SetSort(score)
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit))
+	}
+	cur, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var hits []searchHit
+	for cur.Next(ctx) {
+		data := &struct {
+			blogItem `bson:",inline"`
+			Score    float64 `bson:"score"`
+		}{}
+		if err := cur.Decode(data); err != nil {
+			return nil, err
+		}
+		hits = append(hits, searchHit{Item: &data.blogItem, Score: data.Score})
+	}
+	return hits, cur.Err()
+}
+
+func (s *mongoStore) CreateMany(ctx context.Context, items []*blogItem) ([]*blogItem, error) {
+	created := make([]*blogItem, 0, len(items))
+	docs := make([]interface{}, 0, len(items))
+	ids := make([]primitive.ObjectID, 0, len(items))
+	t := now()
+	for _, item := range items {
+		data := *item
+		// IDs are picked here so a failed insert can be rolled back.
+		data.ID = primitive.NewObjectID()
+		data.Version = 1
+		data.CreatedAt = t
+		data.UpdatedAt = t
+		data.DeletedAt = nil
+		created = append(created, &data)
+		docs = append(docs, &data)
+		ids = append(ids, data.ID)
+	}
+	if len(docs) == 0 {
+		return created, nil
+	}
+	if _, err := s.collection.InsertMany(ctx, docs); err != nil {
+		_, _ = s.collection.DeleteMany(ctx, idsFilter(ids, true))
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *mongoStore) GetMany(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*blogItem, error) {
+	return s.findMany(ctx, idsFilter(ids, false))
+}
+
+func (s *mongoStore) DeleteMany(ctx context.Context, q deleteQuery) (map[primitive.ObjectID]*blogItem, error) {
+	filter := idsFilter(q.IDs, q.Purge && q.IncludeDeleted)
+	if q.AuthorID != "" {
+		filter = append(filter, primitive.E{Key: "author_id", Value: q.AuthorID})
+	}
+	found, err := s.findMany(ctx, filter)
+	if err != nil || len(found) == 0 {
+		return found, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	// Only touch the blogs found above; any that changed in between are
+	// dropped from the result by the filter.
+	filter = append(filter, primitive.E{Key: "_id", Value: bson.D{primitive.E{Key: "$in", Value: ids}}})
+	if q.Purge {
+		if _, err := s.collection.DeleteMany(ctx, filter); err != nil {
+			return nil, err
+		}
+		return found, nil
+	}
+	t := now()
+	update := bson.D{
+		primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "updated_at", Value: t},
+			primitive.E{Key: "deleted_at", Value: t},
+		}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "version", Value: 1}}},
+	}
+	if _, err := s.collection.UpdateMany(ctx, filter, update); err != nil {
+		return nil, err
+	}
+	deleted, err := s.findMany(ctx, bson.D{
+		primitive.E{Key: "_id", Value: bson.D{primitive.E{Key: "$in", Value: ids}}},
+		primitive.E{Key: "deleted_at", Value: t},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// findMany returns the blogs matching filter, keyed by ID.
+func (s *mongoStore) findMany(ctx context.Context, filter bson.D) (map[primitive.ObjectID]*blogItem, error) {
+	cur, err := s.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	found := make(map[primitive.ObjectID]*blogItem)
+	for cur.Next(ctx) {
+		data := &blogItem{}
+		if err := cur.Decode(data); err != nil {
+			return nil, err
+		}
+		found[data.ID] = data
+	}
+	return found, cur.Err()
+}
+
+// idsFilter matches the blogs with any of the given IDs in one query,
+// skipping soft-deleted blogs unless includeDeleted is set.
+func idsFilter(ids []primitive.ObjectID, includeDeleted bool) bson.D {
+	filter := bson.D{primitive.E{Key: "_id", Value: bson.D{primitive.E{Key: "$in", Value: ids}}}}
+	if !includeDeleted {
+		filter = append(filter, primitive.E{Key: "deleted_at", Value: nil})
+	}
+	return filter
+}
+
+// listFilter builds the query for q. Resuming after a blog is a range
+// condition on the sort key and ID, so it stays on the index instead of
+// skipping over earlier pages.
+func listFilter(q listQuery) bson.D {
+	filter := bson.D{}
+	if q.AuthorID != "" {
+		filter = append(filter, primitive.E{Key: "author_id", Value: q.AuthorID})
+	}
+	if !q.IncludeDeleted {
+		filter = append(filter, primitive.E{Key: "deleted_at", Value: nil})
+	}
+	if q.After == nil {
+		return filter
+	}
+	op := "$gt"
+	if q.Descending {
+		op = "$lt"
+	}
+	afterID := bson.D{primitive.E{Key: "_id", Value: bson.D{primitive.E{Key: op, Value: q.After.ID}}}}
+	if q.SortBy == "" {
+		return append(filter, afterID...)
+	}
+	value := q.After.field(q.SortBy)
+	return append(filter, primitive.E{Key: "$or", Value: bson.A{
+		bson.D{primitive.E{Key: q.SortBy, Value: bson.D{primitive.E{Key: op, Value: value}}}},
+		append(bson.D{primitive.E{Key: q.SortBy, Value: value}}, afterID...),
+	}})
+}
+
+func (s *mongoStore) ReserveKey(ctx context.Context, rec *idempotencyRecord) (*idempotencyRecord, error) {
+	// The upsert replaces an expired record. A live one fails it on the
+	// unique _id instead, and is then looked up; should it expire in
+	// between, the upsert is tried again.
+	for {
+		filter := bson.D{
+			primitive.E{Key: "_id", Value: rec.ID},
+			primitive.E{Key: "expires_at", Value: bson.D{primitive.E{Key: "$lte", Value: now()}}},
+		}
+		_, err := s.keys.ReplaceOne(ctx, filter, rec, options.Replace().SetUpsert(true))
+		if err == nil {
+			return nil, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+		stored := &idempotencyRecord{}
+		err = s.keys.FindOne(ctx, bson.D{primitive.E{Key: "_id", Value: rec.ID}}).Decode(stored)
+		if err == nil {
+			return stored, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+	}
+}
+
+func (s *mongoStore) CompleteKey(ctx context.Context, id string, response []byte, expiresAt time.Time) error {
+	_, err := s.keys.UpdateOne(ctx,
+		bson.D{primitive.E{Key: "_id", Value: id}},
+		bson.D{primitive.E{Key: "$set", Value: bson.D{
+			primitive.E{Key: "done", Value: true},
+			primitive.E{Key: "response", Value: response},
+			primitive.E{Key: "expires_at", Value: expiresAt},
+		}}},
+	)
+	return err
+}
+
+func (s *mongoStore) ReleaseKey(ctx context.Context, id string) error {
+	_, err := s.keys.DeleteOne(ctx, bson.D{
+		primitive.E{Key: "_id", Value: id},
+		primitive.E{Key: "done", Value: false},
+	})
+	return err
+}
+
+// ensureIndexes creates the indexes List relies on and the one expiring
+// idempotency records. It is safe to call on every startup.
+func (s *mongoStore) ensureIndexes(ctx context.Context) error {
+	// MongoDB drops expired records within a minute or so; until then
+	// ReserveKey treats them as gone already.
+	_, err := s.keys.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{primitive.E{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{primitive.E{Key: "author_id", Value: 1}, primitive.E{Key: "_id", Value: 1}}},
+		{Keys: bson.D{primitive.E{Key: "title", Value: 1}, primitive.E{Key: "_id", Value: 1}}},
+		{Keys: bson.D{primitive.E{Key: "author_id", Value: 1}, primitive.E{Key: "title", Value: 1}, primitive.E{Key: "_id", Value: 1}}},
+		{
+			Keys: bson.D{primitive.E{Key: "title", Value: "text"}, primitive.E{Key: "content", Value: "text"}},
+			Options: options.Index().
+				SetName("blog_text").
+				SetDefaultLanguage("english").
+				SetWeights(bson.D{
+					primitive.E{Key: "title", Value: titleWeight},
+					primitive.E{Key: "content", Value: contentWeight},
+				}),
+		},
+	})
+	return err
+}
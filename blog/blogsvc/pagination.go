@@ -0,0 +1,84 @@
+package blogsvc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+var errBadPageToken = errors.New("invalid page token")
+
+// pageToken is the position of a listing, handed to clients as an opaque
+// string. It records the query it was issued for so it cannot be replayed
+// against a different filter or order.
+type pageToken struct {
+	AuthorID   string `json:"a,omitempty"`
+	OrderBy    int32  `json:"o,omitempty"`
+	Descending bool   `json:"d,omitempty"`
+	Title      string `json:"t,omitempty"`
+	ID         string `json:"i"`
+}
+
+// listQueryFromRequest turns req into a listQuery, resuming after the blog
+// recorded in its page token if one is given.
+func listQueryFromRequest(req *blogpb.ListBlogRequest) (listQuery, error) {
+	q := listQuery{
+		AuthorID:       req.GetAutherId(),
+		IncludeDeleted: req.GetIncludeDeleted(),
+		Descending:     req.GetDescending(),
+		Limit:          int(req.GetPageSize()),
+	}
+	switch req.GetOrderBy() {
+	case blogpb.ListBlogRequest_CREATE_TIME:
+	case blogpb.ListBlogRequest_TITLE:
+		q.SortBy = "title"
+	default:
+		return q, errors.New("unknown order_by")
+	}
+	if q.Limit < 0 {
+		return q, errors.New("page_size must not be negative")
+	}
+	if req.GetPageToken() == "" {
+		return q, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(req.GetPageToken())
+	if err != nil {
+		return q, errBadPageToken
+	}
+	tok := pageToken{}
+	if err := json.Unmarshal(raw, &tok); err != nil {
+		return q, errBadPageToken
+	}
+	if tok.AuthorID != req.GetAutherId() || tok.OrderBy != int32(req.GetOrderBy()) || tok.Descending != req.GetDescending() {
+		return q, errors.New("page token does not match the request")
+	}
+	oid, err := primitive.ObjectIDFromHex(tok.ID)
+	if err != nil {
+		return q, errBadPageToken
+	}
+	q.After = &blogItem{ID: oid, Title: tok.Title}
+	return q, nil
+}
+
+// encodePageToken returns the token that resumes req's listing after item.
+func encodePageToken(req *blogpb.ListBlogRequest, item *blogItem) string {
+	tok := pageToken{
+		AuthorID:   req.GetAutherId(),
+		OrderBy:    int32(req.GetOrderBy()),
+		Descending: req.GetDescending(),
+		ID:         item.ID.Hex(),
+	}
+	if req.GetOrderBy() == blogpb.ListBlogRequest_TITLE {
+		tok.Title = item.Title
+	}
+	raw, _ := json.Marshal(tok)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
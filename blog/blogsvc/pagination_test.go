@@ -0,0 +1,115 @@
+package blogsvc
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// listAll pages through req's listing and returns the titles and IDs of
+// every blog, in order.
+func listAll(t *testing.T, s *server, req *blogpb.ListBlogRequest) []string {
+	t.Helper()
+	var got []string
+	for pages := 0; ; pages++ {
+		if pages > 100 {
+			t.Fatal("listing does not end")
+		}
+		res, err := s.ListBlogsPage(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ListBlogsPage: %v", err)
+		}
+		for _, blog := range res.GetBlogs() {
+			got = append(got, blog.GetTitle()+"/"+blog.GetId())
+		}
+		if res.GetNextPageToken() == "" {
+			return got
+		}
+		req = proto.Clone(req).(*blogpb.ListBlogRequest)
+		req.PageToken = res.GetNextPageToken()
+	}
+}
+
+func TestListBlogsPageOrder(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer()
+	// Titles repeat so that ties have to be broken by ID, which follows
+	// creation order.
+	var blogs []string
+	for _, b := range []struct{ author, title string }{
+		{"ann", "b"}, {"bob", "a"}, {"ann", "b"}, {"ann", "c"}, {"bob", "a"},
+	} {
+		blog := mustCreate(t, ctx, s, b.author, b.title, "")
+		blogs = append(blogs, blog.GetTitle()+"/"+blog.GetId())
+	}
+	pick := func(indexes ...int) []string {
+		var out []string
+		for _, i := range indexes {
+			out = append(out, blogs[i])
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		req  *blogpb.ListBlogRequest
+		want []string
+	}{
+		{"create time", &blogpb.ListBlogRequest{}, pick(0, 1, 2, 3, 4)},
+		{"create time descending", &blogpb.ListBlogRequest{Descending: true}, pick(4, 3, 2, 1, 0)},
+		{"title", &blogpb.ListBlogRequest{OrderBy: blogpb.ListBlogRequest_TITLE}, pick(1, 4, 0, 2, 3)},
+		{"title descending", &blogpb.ListBlogRequest{OrderBy: blogpb.ListBlogRequest_TITLE, Descending: true}, pick(3, 2, 0, 4, 1)},
+		{"author", &blogpb.ListBlogRequest{AutherId: "ann"}, pick(0, 2, 3)},
+		{"author by title", &blogpb.ListBlogRequest{AutherId: "bob", OrderBy: blogpb.ListBlogRequest_TITLE}, pick(1, 4)},
+	}
+	for _, tt := range tests {
+		for _, pageSize := range []int32{1, 2, 10} {
+			req := proto.Clone(tt.req).(*blogpb.ListBlogRequest)
+			req.PageSize = pageSize
+			if got := listAll(t, s, req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s, page size %d: got %v, want %v", tt.name, pageSize, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestListBlogsPageBadToken(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer()
+	for _, title := range []string{"a", "b", "c"} {
+		mustCreate(t, ctx, s, "ann", title, "")
+	}
+	res, err := s.ListBlogsPage(ctx, &blogpb.ListBlogRequest{PageSize: 1})
+	if err != nil {
+		t.Fatalf("ListBlogsPage: %v", err)
+	}
+	token := res.GetNextPageToken()
+	if token == "" {
+		t.Fatal("no next page token")
+	}
+
+	tests := []struct {
+		name string
+		req  *blogpb.ListBlogRequest
+	}{
+		{"not base64", &blogpb.ListBlogRequest{PageToken: "!!!"}},
+		{"not a token", &blogpb.ListBlogRequest{PageToken: base64.RawURLEncoding.EncodeToString([]byte("{}"))}},
+		{"truncated", &blogpb.ListBlogRequest{PageToken: token[:len(token)-4]}},
+		{"bad ID", &blogpb.ListBlogRequest{PageToken: base64.RawURLEncoding.EncodeToString([]byte(`{"i":"zz"}`))}},
+		{"other author", &blogpb.ListBlogRequest{PageToken: token, AutherId: "bob"}},
+		{"other order", &blogpb.ListBlogRequest{PageToken: token, OrderBy: blogpb.ListBlogRequest_TITLE}},
+		{"other direction", &blogpb.ListBlogRequest{PageToken: token, Descending: true}},
+	}
+	for _, tt := range tests {
+		_, err := s.ListBlogsPage(ctx, tt.req)
+		if code := status.Code(err); code != codes.InvalidArgument {
+			t.Errorf("%s: got code %v (%v), want InvalidArgument", tt.name, code, err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package blogsvc
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultSearchSize = 20
+	maxSearchSize     = 100
+
+	// Title matches count more than content matches. The Mongo text index
+	// uses the same weights.
+	titleWeight   = 3
+	contentWeight = 1
+
+	// snippetRadius is how many bytes of content are kept on each side of
+	// the first match.
+	snippetRadius = 60
+)
+
+// stopWords are skipped by the tokenizer, as MongoDB's English text index
+// does, so common words do not drive the ranking.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "for": true, "if": true, "in": true,
+	"into": true, "is": true, "it": true, "no": true, "not": true, "of": true,
+	"on": true, "or": true, "such": true, "that": true, "the": true,
+	"their": true, "then": true, "there": true, "these": true, "they": true,
+	"this": true, "to": true, "was": true, "will": true, "with": true,
+}
+
+// token is a word of a text and where it sits in it.
+type token struct {
+	term       string
+	start, end int
+}
+
+// tokenize splits text into lower-cased words, dropping stop words.
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		term := strings.ToLower(text[start:end])
+		if !stopWords[term] {
+			tokens = append(tokens, token{term: term, start: start, end: end})
+		}
+		start = -1
+	}
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+	return tokens
+}
+
+// queryTerms returns the distinct terms of a search query.
+func queryTerms(query string) map[string]bool {
+	terms := make(map[string]bool)
+	for _, tok := range tokenize(query) {
+		terms[tok.term] = true
+	}
+	return terms
+}
+
+// fieldScore rates how well text matches terms. Every occurrence counts,
+// damped by the length of the text so short, focused fields win.
+func fieldScore(text string, terms map[string]bool) float64 {
+	tokens := tokenize(text)
+	matches := 0
+	for _, tok := range tokens {
+		if terms[tok.term] {
+			matches++
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	return float64(matches) / math.Sqrt(float64(len(tokens)))
+}
+
+// scoreBlog is the rank of item for terms, zero when nothing matches.
+func scoreBlog(item *blogItem, terms map[string]bool) float64 {
+	return titleWeight*fieldScore(item.Title, terms) + contentWeight*fieldScore(item.Content, terms)
+}
+
+// snippet returns the part of text around its first match as HTML, with
+// every matched word wrapped in <em></em> and the rest escaped, or "" when
+// nothing matches.
+func snippet(text string, terms map[string]bool, radius int) string {
+	var hits []token
+	for _, tok := range tokenize(text) {
+		if terms[tok.term] {
+			hits = append(hits, tok)
+		}
+	}
+	if len(hits) == 0 {
+		return ""
+	}
+	from, to := 0, len(text)
+	if radius > 0 {
+		from = wordBoundary(text, hits[0].start-radius)
+		to = wordBoundary(text, hits[0].end+radius)
+		if to < hits[0].end {
+			to = hits[0].end
+		}
+	}
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("...")
+	}
+	pos := from
+	for _, hit := range hits {
+		if hit.start < from || hit.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:hit.start]))
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(text[hit.start:hit.end]))
+		b.WriteString("</em>")
+		pos = hit.end
+	}
+	b.WriteString(html.EscapeString(text[pos:to]))
+	if to < len(text) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
+
+// wordBoundary moves i into text and back to the start of the word it
+// falls in, so snippets do not cut words or runes in half.
+func wordBoundary(text string, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	if i >= len(text) {
+		return len(text)
+	}
+	for i > 0 && text[i-1] != ' ' && text[i-1] != '\n' {
+		i--
+	}
+	return i
+}
+
+func (s *server) SearchBlogs(ctx context.Context, req *blogpb.SearchBlogsRequest) (*blogpb.SearchBlogsResponse, error) {
+	if err := validate(req, nil); err != nil {
+		return nil, err
+	}
+	terms := queryTerms(req.GetQuery())
+	if len(terms) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Query has no searchable words")
+	}
+	limit := int(req.GetPageSize())
+	if limit <= 0 {
+		limit = defaultSearchSize
+	}
+	if limit > maxSearchSize {
+		limit = maxSearchSize
+	}
+	hits, err := s.store.Search(ctx, searchQuery{
+		Text:     req.GetQuery(),
+		AuthorID: req.GetAutherId(),
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	res := &blogpb.SearchBlogsResponse{}
+	for _, hit := range hits {
+		result := &blogpb.SearchResult{
+			Blog:  hit.Item.toBlog(),
+			Score: hit.Score,
+		}
+		if title := snippet(hit.Item.Title, terms, 0); title != "" {
+			result.Snippets = append(result.Snippets, title)
+		}
+		if content := snippet(hit.Item.Content, terms, snippetRadius); content != "" {
+			result.Snippets = append(result.Snippets, content)
+		}
+		res.Results = append(res.Results, result)
+	}
+	return res, nil
+}
@@ -0,0 +1,98 @@
+package blogsvc
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens := tokenize("The quick, brown fox's 2nd-run! Ünïcode")
+	var terms []string
+	for _, tok := range tokens {
+		terms = append(terms, tok.term)
+	}
+	want := []string{"quick", "brown", "fox", "s", "2nd", "run", "ünïcode"}
+	if !reflect.DeepEqual(terms, want) {
+		t.Errorf("terms = %q, want %q", terms, want)
+	}
+	if first := tokens[0]; first.start != 4 || first.end != 9 {
+		t.Errorf("quick spans [%d, %d), want [4, 9)", first.start, first.end)
+	}
+	if tokenize("the and of") != nil {
+		t.Errorf("stop words were kept")
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  map[string]bool
+	}{
+		{"Go and GO, go!", map[string]bool{"go": true}},
+		{"gRPC streams", map[string]bool{"grpc": true, "streams": true}},
+		{"the of", map[string]bool{}},
+	}
+	for _, tt := range tests {
+		if got := queryTerms(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("queryTerms(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestScoreBlog(t *testing.T) {
+	terms := queryTerms("go")
+	tests := []struct {
+		name string
+		item blogItem
+		want float64
+	}{
+		{"no match", blogItem{Title: "Rust", Content: "Only rust here"}, 0},
+		{"title", blogItem{Title: "Go"}, 3},
+		{"content", blogItem{Content: "go"}, 1},
+		{"both", blogItem{Title: "Go", Content: "go"}, 4},
+		{"every occurrence", blogItem{Content: "go go go go"}, 2},
+	}
+	for _, tt := range tests {
+		if got := scoreBlog(&tt.item, terms); got != tt.want {
+			t.Errorf("%s: score = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Title matches outrank content matches, and short fields long ones.
+	title := scoreBlog(&blogItem{Title: "Learning Go today"}, terms)
+	content := scoreBlog(&blogItem{Content: "Learning Go today"}, terms)
+	long := scoreBlog(&blogItem{Title: "Learning Go today and every other day"}, terms)
+	if !(title > content && title > long) {
+		t.Errorf("title %v, content %v, long title %v: want the short title first", title, content, long)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		query  string
+		radius int
+		want   string
+	}{
+		{"no match", "Nothing here", "go", 0, ""},
+		{"whole text", "Learning Go today", "go", 0, "Learning <em>Go</em> today"},
+		{"every match", "go Go, GO", "go", 0, "<em>go</em> <em>Go</em>, <em>GO</em>"},
+		{"several terms", "fast gRPC streams", "streams grpc", 0, "fast <em>gRPC</em> <em>streams</em>"},
+		{"cut at words", "aaa bbb ccc target ddd eee fff", "target", 5, "...bbb ccc <em>target</em> ddd ..."},
+		{"match at start", "target ddd eee fff", "target", 3, "<em>target</em> ..."},
+		{"matches outside the window", "go aaa bbb ccc go", "go", 7, "<em>go</em> aaa ..."},
+		{"runes kept whole", "ééé target", "target", 2, "ééé <em>target</em>"},
+		{"markup escaped", `<script>alert("go")</script> & go`, "go", 0, `&lt;script&gt;alert(&#34;<em>go</em>&#34;)&lt;/script&gt; &amp; <em>go</em>`},
+	}
+	for _, tt := range tests {
+		got := snippet(tt.text, queryTerms(tt.query), tt.radius)
+		if got != tt.want {
+			t.Errorf("%s: snippet = %q, want %q", tt.name, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("%s: snippet %q is not valid UTF-8", tt.name, got)
+		}
+	}
+}
@@ -0,0 +1,401 @@
+// Package blogsvc implements the BlogService on top of a pluggable
+// BlogStore.
+package blogsvc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type server struct {
+	store  BlogStore
+	events *watchHub
+	// authEnabled makes handlers check the caller's identity, which the
+	// auth interceptors put in the context.
+	authEnabled bool
+}
+
+type blogItem struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	AuthorID string             `bson:"author_id"`
+	Content  string             `bson:"content"`
+	Title    string             `bson:"title"`
+	Version  int64              `bson:"version"`
+
+	CreatedAt time.Time  `bson:"created_at"`
+	UpdatedAt time.Time  `bson:"updated_at"`
+	DeletedAt *time.Time `bson:"deleted_at,omitempty"`
+}
+
+// updatableFields maps the Blog fields UpdateBlog may write to their bson names.
+var updatableFields = map[string]string{
+	"auther_id": "author_id",
+	"title":     "title",
+	"content":   "content",
+}
+
+// field returns the updatable field stored under the given bson name.
+func (item *blogItem) field(name string) string {
+	switch name {
+	case "author_id":
+		return item.AuthorID
+	case "title":
+		return item.Title
+	case "content":
+		return item.Content
+	}
+	return ""
+}
+
+// setField sets the updatable field stored under the given bson name.
+func (item *blogItem) setField(name, value string) {
+	switch name {
+	case "author_id":
+		item.AuthorID = value
+	case "title":
+		item.Title = value
+	case "content":
+		item.Content = value
+	}
+}
+
+// touch records a modification of item by bumping its version and
+// update time.
+func (item *blogItem) touch() {
+	item.Version++
+	item.UpdatedAt = now()
+}
+
+func (item *blogItem) toBlog() *blogpb.Blog {
+	createdAt := item.CreatedAt
+	if createdAt.IsZero() {
+		// Blogs written before timestamps were stored still carry their
+		// creation time in the ObjectID.
+		createdAt = item.ID.Timestamp()
+	}
+	blog := &blogpb.Blog{
+		Id:        item.ID.Hex(),
+		AutherId:  item.AuthorID,
+		Title:     item.Title,
+		Content:   item.Content,
+		Version:   item.Version,
+		CreatedAt: timestamppb.New(createdAt),
+	}
+	if !item.UpdatedAt.IsZero() {
+		blog.UpdatedAt = timestamppb.New(item.UpdatedAt)
+	}
+	if item.DeletedAt != nil {
+		blog.DeletedAt = timestamppb.New(*item.DeletedAt)
+	}
+	return blog
+}
+
+// newItem turns a blog to be created into a blogItem written as the caller.
+func (s *server) newItem(ctx context.Context, blog *blogpb.Blog) (*blogItem, error) {
+	data := &blogItem{
+		AuthorID: blog.GetAutherId(),
+		Content:  blog.GetContent(),
+		Title:    blog.GetTitle(),
+	}
+	if id, ok, err := s.caller(ctx); err != nil {
+		return nil, err
+	} else if ok && (data.AuthorID == "" || !id.IsAdmin()) {
+		// Blogs are written as the caller; only admins pick another author.
+		data.AuthorID = id.Subject
+	}
+	return data, nil
+}
+
+func (s *server) CreateBlog(ctx context.Context, req *blogpb.CreateBlogRequest) (*blogpb.CreateBlogResponse, error) {
+	data, err := s.newItem(ctx, req.GetBlog())
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(&blogpb.CreateBlogRequest{Blog: data.toBlog()}, nil); err != nil {
+		return nil, err
+	}
+	created, err := s.store.Create(ctx, data)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	s.events.publish(blogpb.BlogEvent_CREATED, created)
+	return &blogpb.CreateBlogResponse{
+		Blog: created.toBlog(),
+	}, nil
+}
+
+func (s *server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blogpb.ReadBlogResponse, error) {
+	blogID := req.GetBlogId()
+	oid, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprint("Cannot parse ID"))
+	}
+	data, err := s.store.Get(ctx, oid)
+	if err == errNotFound {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Cannot find blog with specified ID: %v", err),
+		)
+	}
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	return &blogpb.ReadBlogResponse{
+		Blog: data.toBlog(),
+	}, nil
+
+}
+
+func (s *server) UpdateBlog(ctx context.Context, req *blogpb.UpdateBlogRequest) (*blogpb.UpdateBlogResponse, error) {
+	blog := req.GetBlog()
+	oid, err := primitive.ObjectIDFromHex(blog.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprint("Cannot parse ID"))
+	}
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"auther_id", "title", "content"}
+	}
+	fields := make([]string, 0, len(paths))
+	checked := map[string]bool{"blog": true}
+	for _, path := range paths {
+		field, ok := updatableFields[path]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Cannot update field %q", path)
+		}
+		fields = append(fields, field)
+		checked["blog."+path] = true
+	}
+	if err := validate(req, checked); err != nil {
+		return nil, err
+	}
+	data := &blogItem{
+		ID:       oid,
+		AuthorID: blog.GetAutherId(),
+		Title:    blog.GetTitle(),
+		Content:  blog.GetContent(),
+		Version:  req.GetExpectedVersion(),
+	}
+	if data.Version, err = s.ownerAllowed(ctx, oid, data.Version); err != nil {
+		return nil, err
+	}
+	prevAuthorID := ""
+	for _, field := range fields {
+		if field != "author_id" {
+			continue
+		}
+		if err := s.authorAllowed(ctx, data.AuthorID); err != nil {
+			return nil, err
+		}
+		// Watchers of the old author have to see the blog move away, so
+		// the write is pinned to the version the old author was read at.
+		current, err := s.store.Get(ctx, oid)
+		if err == errNotFound {
+			return nil, status.Errorf(
+				codes.NotFound,
+				fmt.Sprintf("Cannot find blog with specified ID: %v", err),
+			)
+		}
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				fmt.Sprintf("Internal error: %v", err),
+			)
+		}
+		if data.Version == 0 {
+			data.Version = current.Version
+		}
+		prevAuthorID = current.AuthorID
+	}
+	updated, updateErr := s.store.Update(ctx, data, fields)
+	if updateErr == errNotFound {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Cannot find blog with specified ID: %v", updateErr),
+		)
+	}
+	if updateErr == errVersionMismatch {
+		return nil, status.Errorf(
+			codes.Aborted,
+			fmt.Sprintf("Blog was modified concurrently: %v", updateErr),
+		)
+	}
+	if updateErr != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", updateErr),
+		)
+	}
+	if prevAuthorID == "" {
+		prevAuthorID = updated.AuthorID
+	}
+	s.events.publishMove(blogpb.BlogEvent_UPDATED, updated, prevAuthorID)
+	return &blogpb.UpdateBlogResponse{
+		Blog: updated.toBlog(),
+	}, nil
+}
+
+func (s *server) DeleteBlog(ctx context.Context, req *blogpb.DeleteBlogRequest) (*blogpb.DeleteBlogResponse, error) {
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprint("Cannot parse ID"))
+	}
+	version, err := s.ownerAllowed(ctx, oid, req.GetExpectedVersion())
+	if status.Code(err) == codes.NotFound && req.GetPurge() {
+		// The blog may be soft-deleted, which only admins get to see.
+		version, err = req.GetExpectedVersion(), s.adminAllowed(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var deleted *blogItem
+	var deleteErr error
+	if req.GetPurge() {
+		deleted, deleteErr = s.store.Purge(ctx, oid, version)
+	} else {
+		deleted, deleteErr = s.store.Delete(ctx, oid, version)
+	}
+	if deleteErr == errNotFound {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Cannot find blog with specified ID: %v", deleteErr),
+		)
+	}
+	if deleteErr == errVersionMismatch {
+		return nil, status.Errorf(
+			codes.Aborted,
+			fmt.Sprintf("Blog was modified concurrently: %v", deleteErr),
+		)
+	}
+	if deleteErr != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", deleteErr),
+		)
+	}
+	s.events.publish(blogpb.BlogEvent_DELETED, deleted)
+	return &blogpb.DeleteBlogResponse{
+		BlogId: req.GetBlogId(),
+	}, nil
+}
+
+func (s *server) UndeleteBlog(ctx context.Context, req *blogpb.UndeleteBlogRequest) (*blogpb.UndeleteBlogResponse, error) {
+	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprint("Cannot parse ID"))
+	}
+	if err := s.adminAllowed(ctx); err != nil {
+		return nil, err
+	}
+	restored, undeleteErr := s.store.Undelete(ctx, oid, req.GetExpectedVersion())
+	if undeleteErr == errNotFound {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Cannot find blog with specified ID: %v", undeleteErr),
+		)
+	}
+	if undeleteErr == errVersionMismatch {
+		return nil, status.Errorf(
+			codes.Aborted,
+			fmt.Sprintf("Blog was modified concurrently: %v", undeleteErr),
+		)
+	}
+	if undeleteErr != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", undeleteErr),
+		)
+	}
+	s.events.publish(blogpb.BlogEvent_UPDATED, restored)
+	return &blogpb.UndeleteBlogResponse{
+		Blog: restored.toBlog(),
+	}, nil
+}
+
+func (s *server) ListBlog(req *blogpb.ListBlogRequest, stream blogpb.BlogService_ListBlogServer) error {
+	if err := validate(req, nil); err != nil {
+		return err
+	}
+	q, err := listQueryFromRequest(req)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid list request: %v", err)
+	}
+	if q.IncludeDeleted {
+		if err := s.adminAllowed(stream.Context()); err != nil {
+			return err
+		}
+	}
+	if q.Limit > maxPageSize {
+		q.Limit = maxPageSize
+	}
+	err = s.store.List(stream.Context(), q, func(data *blogItem) error {
+		return stream.Send(&blogpb.ListBlogResponse{
+			Blog:      data.toBlog(),
+			PageToken: encodePageToken(req, data),
+		})
+	})
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	return nil
+}
+
+func (s *server) ListBlogsPage(ctx context.Context, req *blogpb.ListBlogRequest) (*blogpb.ListBlogsPageResponse, error) {
+	if err := validate(req, nil); err != nil {
+		return nil, err
+	}
+	q, err := listQueryFromRequest(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid list request: %v", err)
+	}
+	if q.IncludeDeleted {
+		if err := s.adminAllowed(ctx); err != nil {
+			return nil, err
+		}
+	}
+	pageSize := q.Limit
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	// Ask for one extra blog to learn whether another page follows.
+	q.Limit = pageSize + 1
+	var items []*blogItem
+	err = s.store.List(ctx, q, func(data *blogItem) error {
+		items = append(items, data)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	res := &blogpb.ListBlogsPageResponse{}
+	if len(items) > pageSize {
+		items = items[:pageSize]
+		res.NextPageToken = encodePageToken(req, items[len(items)-1])
+	}
+	for _, data := range items {
+		res.Blogs = append(res.Blogs, data.toBlog())
+	}
+	return res, nil
+}
@@ -0,0 +1,337 @@
+package blogsvc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// newTestServer returns a server on the memory store with auth off.
+func newTestServer() *server {
+	return &server{store: newMemoryStore(), events: newWatchHub()}
+}
+
+// mustCreate creates a blog through the handler and returns it.
+func mustCreate(t *testing.T, ctx context.Context, s *server, author, title, content string) *blogpb.Blog {
+	t.Helper()
+	res, err := s.CreateBlog(ctx, &blogpb.CreateBlogRequest{
+		Blog: &blogpb.Blog{AutherId: author, Title: title, Content: content},
+	})
+	if err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+	return res.GetBlog()
+}
+
+// wantCode fails the test unless err carries code.
+func wantCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != code {
+		t.Fatalf("got code %v (%v), want %v", got, err, code)
+	}
+}
+
+func TestCreateReadUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer()
+
+	created := mustCreate(t, ctx, s, "ann", "Hello", "First post")
+	if created.GetId() == "" || created.GetVersion() != 1 || created.GetCreatedAt() == nil {
+		t.Fatalf("created blog = %v, want an ID, version 1 and a creation time", created)
+	}
+
+	read, err := s.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: created.GetId()})
+	if err != nil {
+		t.Fatalf("ReadBlog: %v", err)
+	}
+	if read.GetBlog().GetTitle() != "Hello" || read.GetBlog().GetContent() != "First post" {
+		t.Errorf("read blog = %v, want the created one", read.GetBlog())
+	}
+
+	updated, err := s.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{
+		Blog: &blogpb.Blog{Id: created.GetId(), AutherId: "ann", Title: "Hello again", Content: "Edited"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateBlog: %v", err)
+	}
+	if got := updated.GetBlog(); got.GetTitle() != "Hello again" || got.GetContent() != "Edited" || got.GetVersion() != 2 {
+		t.Errorf("updated blog = %v, want the new title and content at version 2", got)
+	}
+
+	if _, err := s.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: created.GetId()}); err != nil {
+		t.Fatalf("DeleteBlog: %v", err)
+	}
+	_, err = s.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: created.GetId()})
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestMissingBlog(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer()
+	missing := primitive.NewObjectID().Hex()
+
+	_, err := s.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: missing})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{
+		Blog: &blogpb.Blog{Id: missing, AutherId: "ann", Title: "t"},
+	})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: missing})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: "not-an-id"})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestUpdateMask(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer()
+	created := mustCreate(t, ctx, s, "ann", "Hello", "First post")
+
+	for _, paths := range [][]string{{"title", "votes"}, {"id"}, {"version"}, {"blog.title"}} {
+		_, err := s.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{
+			Blog:       &blogpb.Blog{Id: created.GetId(), Title: "Masked"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("mask %v: got %v, want InvalidArgument", paths, err)
+		}
+	}
+
+	// Fields left out of the mask keep their values, even when the
+	// request clears them.
+	res, err := s.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{
+		Blog:       &blogpb.Blog{Id: created.GetId(), Title: "Hello again"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateBlog: %v", err)
+	}
+	got := res.GetBlog()
+	if got.GetTitle() != "Hello again" || got.GetContent() != "First post" || got.GetAutherId() != "ann" {
+		t.Errorf("updated blog = %v, want only the title changed", got)
+	}
+
+	// The response is the stored blog, with what the server sets itself.
+	read, err := s.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: created.GetId()})
+	if err != nil {
+		t.Fatalf("ReadBlog: %v", err)
+	}
+	if !proto.Equal(got, read.GetBlog()) {
+		t.Errorf("UpdateBlog returned %v, stored is %v", got, read.GetBlog())
+	}
+	if got.GetVersion() != 2 || !proto.Equal(got.GetCreatedAt(), created.GetCreatedAt()) || got.GetUpdatedAt() == nil {
+		t.Errorf("updated blog = %v, want version 2, the creation time kept and an update time", got)
+	}
+}
+
+func TestUpdateVersionMismatch(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer()
+	created := mustCreate(t, ctx, s, "ann", "Hello", "")
+
+	_, err := s.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{
+		Blog:            &blogpb.Blog{Id: created.GetId(), AutherId: "ann", Title: "Stale"},
+		ExpectedVersion: created.GetVersion() + 1,
+	})
+	wantCode(t, err, codes.Aborted)
+	read, err := s.ReadBlog(ctx, &blogpb.ReadBlogRequest{BlogId: created.GetId()})
+	if err != nil {
+		t.Fatalf("ReadBlog: %v", err)
+	}
+	if read.GetBlog().GetTitle() != "Hello" {
+		t.Errorf("title = %q after a rejected update, want it unchanged", read.GetBlog().GetTitle())
+	}
+}
+
+func TestExpectedVersion(t *testing.T) {
+	ctx := context.Background()
+	s := newTestServer()
+	created := mustCreate(t, ctx, s, "ann", "Hello", "")
+	id := created.GetId()
+	update := func(version int64, title string) (*blogpb.UpdateBlogResponse, error) {
+		return s.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{
+			Blog:            &blogpb.Blog{Id: id, Title: title},
+			UpdateMask:      &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+			ExpectedVersion: version,
+		})
+	}
+
+	res, err := update(1, "Matching version")
+	if err != nil {
+		t.Fatalf("update at the current version: %v", err)
+	}
+	if res.GetBlog().GetVersion() != 2 {
+		t.Errorf("version = %d, want 2", res.GetBlog().GetVersion())
+	}
+	_, err = update(1, "Stale version")
+	wantCode(t, err, codes.Aborted)
+	res, err = update(0, "Any version")
+	if err != nil {
+		t.Fatalf("update without a version: %v", err)
+	}
+	if res.GetBlog().GetVersion() != 3 || res.GetBlog().GetTitle() != "Any version" {
+		t.Errorf("blog = %v, want title %q at version 3", res.GetBlog(), "Any version")
+	}
+
+	_, err = s.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: id, ExpectedVersion: 2})
+	wantCode(t, err, codes.Aborted)
+	if _, err := s.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: id, ExpectedVersion: 3}); err != nil {
+		t.Fatalf("delete at the current version: %v", err)
+	}
+	_, err = s.UndeleteBlog(ctx, &blogpb.UndeleteBlogRequest{BlogId: id, ExpectedVersion: 3})
+	wantCode(t, err, codes.Aborted)
+	if _, err := s.UndeleteBlog(ctx, &blogpb.UndeleteBlogRequest{BlogId: id}); err != nil {
+		t.Fatalf("undelete without a version: %v", err)
+	}
+}
+
+func TestMemoryStoreVersionCheck(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	item, err := store.Create(ctx, &blogItem{AuthorID: "ann", Title: "Hello"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	for _, tt := range []struct {
+		version int64
+		want    error
+	}{
+		{0, nil},
+		{2, nil},
+		{1, errVersionMismatch},
+		{5, errVersionMismatch},
+	} {
+		_, err := store.Update(ctx, &blogItem{ID: item.ID, Title: "t", Version: tt.version}, []string{"title"})
+		if err != tt.want {
+			t.Errorf("Update at version %d: got %v, want %v", tt.version, err, tt.want)
+		}
+	}
+}
+
+// listStream collects the blogs ListBlog sends.
+type listStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	blogs []*blogpb.Blog
+}
+
+func (s *listStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *listStream) Send(res *blogpb.ListBlogResponse) error {
+	s.blogs = append(s.blogs, res.GetBlog())
+	return nil
+}
+
+// listIDs returns the IDs of the blogs ListBlogsPage and ListBlog return
+// for req, after checking that both agree.
+func listIDs(t *testing.T, ctx context.Context, s *server, req *blogpb.ListBlogRequest) ([]string, error) {
+	t.Helper()
+	res, err := s.ListBlogsPage(ctx, req)
+	stream := &listStream{ctx: ctx}
+	streamErr := s.ListBlog(req, stream)
+	if status.Code(err) != status.Code(streamErr) {
+		t.Fatalf("ListBlogsPage failed with %v, ListBlog with %v", err, streamErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ids, streamed []string
+	for _, blog := range res.GetBlogs() {
+		ids = append(ids, blog.GetId())
+	}
+	for _, blog := range stream.blogs {
+		streamed = append(streamed, blog.GetId())
+	}
+	if !reflect.DeepEqual(ids, streamed) {
+		t.Fatalf("ListBlogsPage returned %v, ListBlog %v", ids, streamed)
+	}
+	return ids, nil
+}
+
+func TestSoftDelete(t *testing.T) {
+	s := newTestServer()
+	s.authEnabled = true
+	admin := auth.NewContext(context.Background(), auth.Identity{Subject: "root", Roles: []string{auth.AdminRole}})
+	ann := auth.NewContext(context.Background(), auth.Identity{Subject: "ann"})
+
+	kept := mustCreate(t, ann, s, "ann", "Kept", "")
+	deleted := mustCreate(t, ann, s, "ann", "Deleted", "")
+	if _, err := s.DeleteBlog(ann, &blogpb.DeleteBlogRequest{BlogId: deleted.GetId()}); err != nil {
+		t.Fatalf("DeleteBlog: %v", err)
+	}
+
+	_, err := s.ReadBlog(ann, &blogpb.ReadBlogRequest{BlogId: deleted.GetId()})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.DeleteBlog(ann, &blogpb.DeleteBlogRequest{BlogId: deleted.GetId()})
+	wantCode(t, err, codes.NotFound)
+	if ids, _ := listIDs(t, ann, s, &blogpb.ListBlogRequest{}); !reflect.DeepEqual(ids, []string{kept.GetId()}) {
+		t.Errorf("listed %v, want only the kept blog", ids)
+	}
+	_, err = listIDs(t, ann, s, &blogpb.ListBlogRequest{IncludeDeleted: true})
+	wantCode(t, err, codes.PermissionDenied)
+	ids, err := listIDs(t, admin, s, &blogpb.ListBlogRequest{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("listing deleted blogs as admin: %v", err)
+	}
+	if !reflect.DeepEqual(ids, []string{kept.GetId(), deleted.GetId()}) {
+		t.Errorf("admin listed %v, want both blogs", ids)
+	}
+
+	_, err = s.UndeleteBlog(ann, &blogpb.UndeleteBlogRequest{BlogId: deleted.GetId()})
+	wantCode(t, err, codes.PermissionDenied)
+	restored, err := s.UndeleteBlog(admin, &blogpb.UndeleteBlogRequest{BlogId: deleted.GetId()})
+	if err != nil {
+		t.Fatalf("UndeleteBlog: %v", err)
+	}
+	if restored.GetBlog().GetDeletedAt() != nil {
+		t.Errorf("restored blog still has a deletion time")
+	}
+	if _, err := s.ReadBlog(ann, &blogpb.ReadBlogRequest{BlogId: deleted.GetId()}); err != nil {
+		t.Errorf("reading the restored blog: %v", err)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	s := newTestServer()
+	s.authEnabled = true
+	admin := auth.NewContext(context.Background(), auth.Identity{Subject: "root", Roles: []string{auth.AdminRole}})
+	ann := auth.NewContext(context.Background(), auth.Identity{Subject: "ann"})
+
+	live := mustCreate(t, ann, s, "ann", "Live", "")
+	if _, err := s.DeleteBlog(ann, &blogpb.DeleteBlogRequest{BlogId: live.GetId(), Purge: true}); err != nil {
+		t.Fatalf("purging a live blog: %v", err)
+	}
+
+	// A soft-deleted blog is hidden from its author, so only admins may
+	// purge it.
+	soft := mustCreate(t, ann, s, "ann", "Soft", "")
+	if _, err := s.DeleteBlog(ann, &blogpb.DeleteBlogRequest{BlogId: soft.GetId()}); err != nil {
+		t.Fatalf("DeleteBlog: %v", err)
+	}
+	_, err := s.DeleteBlog(ann, &blogpb.DeleteBlogRequest{BlogId: soft.GetId(), Purge: true})
+	wantCode(t, err, codes.PermissionDenied)
+	if _, err := s.DeleteBlog(admin, &blogpb.DeleteBlogRequest{BlogId: soft.GetId(), Purge: true}); err != nil {
+		t.Fatalf("purging a soft-deleted blog as admin: %v", err)
+	}
+
+	ids, err := listIDs(t, admin, s, &blogpb.ListBlogRequest{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("listing deleted blogs as admin: %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("listed %v after purging, want nothing", ids)
+	}
+	_, err = s.UndeleteBlog(admin, &blogpb.UndeleteBlogRequest{BlogId: soft.GetId()})
+	wantCode(t, err, codes.NotFound)
+}
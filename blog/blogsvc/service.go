@@ -0,0 +1,128 @@
+package blogsvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"github.com/shivkumar123g/grpc_go_course/config"
+	"github.com/shivkumar123g/grpc_go_course/tracing"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"google.golang.org/grpc"
+)
+
+// Service is a BlogService together with the storage it owns.
+type Service struct {
+	server *server
+	// client is the Mongo connection of the mongo store, nil otherwise.
+	client *mongo.Client
+	// pingInterval is how often Monitor checks the database.
+	pingInterval time.Duration
+	// idempotencyTTL is how long responses are kept for replaying.
+	idempotencyTTL time.Duration
+}
+
+// Open connects the storage backend cfg selects and returns a Service
+// writing to it. Handlers check the caller's identity when
+// cfg.AuthKeyFile is set, so the server must then run the auth
+// interceptors, followed by the Service's own.
+func Open(ctx context.Context, cfg config.Blog) (*Service, error) {
+	svc := &Service{idempotencyTTL: cfg.IdempotencyTTL}
+	var store BlogStore
+	switch cfg.Store {
+	case "memory":
+		slog.Info("Using in-memory blog store")
+		store = newMemoryStore()
+	case "mongo":
+		//connect to mongodb
+		slog.Info("Connecting to MongoDb")
+		client, err := mongo.NewClient(options.Client().
+			ApplyURI(cfg.Mongo.URI).
+			SetMonitor(tracing.MongoMonitor()))
+		if err != nil {
+			return nil, err
+		}
+		if err := client.Connect(ctx); err != nil {
+			return nil, err
+		}
+		svc.client = client
+		svc.pingInterval = cfg.Mongo.PingInterval
+		db := client.Database(cfg.Mongo.Database)
+		mongoStore := newMongoStore(
+			db.Collection(cfg.Mongo.Collection),
+			db.Collection(cfg.Mongo.Collection+keysCollectionSuffix),
+		)
+		if err := mongoStore.ensureIndexes(ctx); err != nil {
+			svc.Close(ctx)
+			return nil, fmt.Errorf("creating indexes: %v", err)
+		}
+		store = mongoStore
+	default:
+		return nil, fmt.Errorf("unknown store %q, want mongo or memory", cfg.Store)
+	}
+	svc.server = &server{
+		store:       store,
+		events:      newWatchHub(),
+		authEnabled: cfg.AuthKeyFile != "",
+	}
+	return svc, nil
+}
+
+// Register adds the BlogService to s.
+func (svc *Service) Register(s *grpc.Server) {
+	blogpb.RegisterBlogServiceServer(s, svc.server)
+}
+
+// Ping checks that the storage can be reached.
+func (svc *Service) Ping(ctx context.Context) error {
+	if svc.client == nil {
+		return nil
+	}
+	return svc.client.Ping(ctx, readpref.Primary())
+}
+
+// Monitor calls report with the result of Ping right away and then
+// periodically, until ctx is done. Storage that cannot fail is checked
+// only once.
+func (svc *Service) Monitor(ctx context.Context, report func(err error)) {
+	if svc.client == nil {
+		report(nil)
+		return
+	}
+	ticker := time.NewTicker(svc.pingInterval)
+	defer ticker.Stop()
+	for {
+		pingCtx, cancel := context.WithTimeout(ctx, svc.pingInterval)
+		err := svc.Ping(pingCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		report(err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain ends the WatchBlogs streams, which would otherwise keep a
+// graceful stop waiting until its deadline. Call it when the server
+// starts shutting down.
+func (svc *Service) Drain() {
+	svc.server.events.close()
+}
+
+// Close releases the storage. Call it once the gRPC server has stopped.
+func (svc *Service) Close(ctx context.Context) error {
+	if svc.client == nil {
+		return nil
+	}
+	slog.Info("Closing the Mongodb Connection")
+	return svc.client.Disconnect(ctx)
+}
@@ -0,0 +1,131 @@
+package blogsvc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// errNotFound is returned by a BlogStore when no blog matches the given ID.
+	errNotFound = errors.New("blog not found")
+	// errVersionMismatch is returned by a BlogStore when a write expected a
+	// different version than the one stored.
+	errVersionMismatch = errors.New("blog version does not match")
+)
+
+// BlogStore is the storage the blog server depends on. Every handler goes
+// through it, so the backend can be picked at startup.
+//
+// Get, Update and Delete treat soft-deleted blogs as missing.
+type BlogStore interface {
+	// Create stores a new blog at version 1 and returns it with its
+	// generated ID and timestamps.
+	Create(ctx context.Context, item *blogItem) (*blogItem, error)
+	// Get returns the blog with the given ID or errNotFound.
+	Get(ctx context.Context, id primitive.ObjectID) (*blogItem, error)
+	// Update writes the named bson fields of item to the stored blog with
+	// the same ID, bumps its version and returns the blog as stored
+	// afterwards, or errNotFound. A non-zero item.Version must match the
+	// stored version or errVersionMismatch is returned and nothing changes.
+	Update(ctx context.Context, item *blogItem, fields []string) (*blogItem, error)
+	// Delete soft-deletes the blog with the given ID and returns it, or
+	// returns errNotFound. A non-zero version must match the stored one as
+	// for Update.
+	Delete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error)
+	// Undelete restores a soft-deleted blog and returns it. Restoring a
+	// blog that is not deleted returns it unchanged.
+	Undelete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error)
+	// Purge removes the blog with the given ID for good, whether or not it
+	// is soft-deleted, and returns it as it was last stored.
+	Purge(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error)
+	// List calls fn for every blog matching q, in q's order, until fn
+	// returns an error.
+	List(ctx context.Context, q listQuery, fn func(*blogItem) error) error
+	// Search returns the live blogs matching q's words, best match first.
+	Search(ctx context.Context, q searchQuery) ([]searchHit, error)
+
+	// CreateMany stores new blogs as Create does, in order. Either all of
+	// them are stored or none are.
+	CreateMany(ctx context.Context, items []*blogItem) ([]*blogItem, error)
+	// GetMany returns the live blogs with the given IDs, keyed by ID.
+	// Missing IDs are left out.
+	GetMany(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*blogItem, error)
+	// DeleteMany removes the blogs q selects and returns them, keyed by ID,
+	// as they were left: soft-deleted, or as last stored when purged.
+	DeleteMany(ctx context.Context, q deleteQuery) (map[primitive.ObjectID]*blogItem, error)
+
+	// ReserveKey stores rec, a pending idempotency record, unless an
+	// unexpired record with the same ID exists. It returns that record, or
+	// nil when rec was stored. Expired records are replaced.
+	ReserveKey(ctx context.Context, rec *idempotencyRecord) (*idempotencyRecord, error)
+	// CompleteKey stores the response of the call holding the record with
+	// the given ID and keeps the record until expiresAt.
+	CompleteKey(ctx context.Context, id string, response []byte, expiresAt time.Time) error
+	// ReleaseKey removes the record with the given ID if it is still
+	// pending, so that the call can be made again.
+	ReleaseKey(ctx context.Context, id string) error
+}
+
+// listQuery selects and orders the blogs returned by BlogStore.List.
+type listQuery struct {
+	// AuthorID restricts the listing to one author when set.
+	AuthorID string
+	// IncludeDeleted also lists soft-deleted blogs.
+	IncludeDeleted bool
+	// SortBy is the bson field blogs are ordered by, with the ID breaking
+	// ties. Empty means ID order, which is creation order for ObjectIDs.
+	SortBy     string
+	Descending bool
+	// After, when set, starts the listing strictly after this blog.
+	After *blogItem
+	// Limit caps the number of blogs listed. Zero means no limit.
+	Limit int
+}
+
+// deleteQuery selects the blogs removed by BlogStore.DeleteMany.
+type deleteQuery struct {
+	IDs []primitive.ObjectID
+	// AuthorID, when set, leaves blogs of other authors alone.
+	AuthorID string
+	// Purge removes the blogs for good instead of soft-deleting them.
+	Purge bool
+	// IncludeDeleted also purges blogs that are already soft-deleted.
+	IncludeDeleted bool
+}
+
+// searchQuery selects the blogs returned by BlogStore.Search.
+type searchQuery struct {
+	Text string
+	// AuthorID restricts the search to one author when set.
+	AuthorID string
+	Limit    int
+}
+
+// searchHit is a blog found by BlogStore.Search and its relevance.
+type searchHit struct {
+	Item  *blogItem
+	Score float64
+}
+
+// idempotencyRecord remembers a call made with an idempotency key.
+type idempotencyRecord struct {
+	// ID identifies the key within the caller that sent it.
+	ID string `bson:"_id"`
+	// RequestHash tells calls reusing the key with another request apart.
+	RequestHash []byte `bson:"request_hash"`
+	// Done is set once the call has succeeded and Response holds its
+	// result. Until then the call is in progress.
+	Done     bool   `bson:"done"`
+	Response []byte `bson:"response,omitempty"`
+	// ExpiresAt is when the record may be dropped and the key reused.
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// now returns the current time at the precision MongoDB stores, so every
+// backend hands out the same timestamps.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
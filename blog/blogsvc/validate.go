@@ -0,0 +1,116 @@
+package blogsvc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// patterns caches the compiled (rules).pattern expressions.
+var patterns sync.Map
+
+// validate checks msg against the (rules) options declared on its fields in
+// blog.proto. If only is non-nil, only the field paths it lists, such as
+// "blog.title", are checked. The error carries a google.rpc.BadRequest
+// naming every offending field.
+func validate(msg proto.Message, only map[string]bool) error {
+	return badRequest(fieldViolations(msg, "", only))
+}
+
+// fieldViolations lists the rule violations of msg as validate finds them,
+// with every field path prefixed by prefix.
+func fieldViolations(msg proto.Message, prefix string, only map[string]bool) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	validateMessage(msg.ProtoReflect(), prefix, only, &violations)
+	return violations
+}
+
+// badRequest turns violations into an INVALID_ARGUMENT error, or nil if
+// there are none.
+func badRequest(violations []*errdetails.BadRequest_FieldViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	text := fmt.Sprintf("Invalid %s: %s", violations[0].Field, violations[0].Description)
+	if len(violations) > 1 {
+		text += fmt.Sprintf(" (and %d more)", len(violations)-1)
+	}
+	st := status.New(codes.InvalidArgument, text)
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func validateMessage(m protoreflect.Message, prefix string, only map[string]bool, violations *[]*errdetails.BadRequest_FieldViolation) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+		if fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap() && m.Has(fd) {
+			validateMessage(m.Get(fd).Message(), path+".", only, violations)
+		}
+		if only != nil && !only[path] {
+			continue
+		}
+		rules, ok := proto.GetExtension(fd.Options(), blogpb.E_Rules).(*blogpb.FieldRules)
+		if !ok || rules == nil {
+			continue
+		}
+		if msg := checkField(m, fd, rules); msg != "" {
+			*violations = append(*violations, &errdetails.BadRequest_FieldViolation{
+				Field:       path,
+				Description: msg,
+			})
+		}
+	}
+}
+
+// checkField returns what is wrong with a field, or "" if it obeys rules.
+func checkField(m protoreflect.Message, fd protoreflect.FieldDescriptor, rules *blogpb.FieldRules) string {
+	if fd.Kind() != protoreflect.StringKind {
+		if rules.GetRequired() && !m.Has(fd) {
+			return "is required"
+		}
+		return ""
+	}
+	value := m.Get(fd).String()
+	if rules.GetRequired() && strings.TrimSpace(value) == "" {
+		return "is required"
+	}
+	if max := rules.GetMaxLen(); max > 0 && utf8.RuneCountInString(value) > int(max) {
+		return fmt.Sprintf("must be at most %d characters", max)
+	}
+	if pattern := rules.GetPattern(); pattern != "" && value != "" {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return fmt.Sprintf("has an invalid rule: %v", err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Sprintf("must match %s", pattern)
+		}
+	}
+	return ""
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,105 @@
+package blogsvc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidate(t *testing.T) {
+	valid := func() *blogpb.Blog {
+		return &blogpb.Blog{AutherId: "ann.lee@example_1-2", Title: "Hello", Content: "Body"}
+	}
+	with := func(change func(*blogpb.Blog)) *blogpb.CreateBlogRequest {
+		blog := valid()
+		change(blog)
+		return &blogpb.CreateBlogRequest{Blog: blog}
+	}
+
+	tests := []struct {
+		name string
+		msg  proto.Message
+		only map[string]bool
+		// want maps the offending field paths to their descriptions.
+		want map[string]string
+	}{
+		{"valid", &blogpb.CreateBlogRequest{Blog: valid()}, nil, nil},
+		{"missing blog", &blogpb.CreateBlogRequest{}, nil, map[string]string{
+			"blog": "is required",
+		}},
+		{"empty blog", &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{}}, nil, map[string]string{
+			"blog.auther_id": "is required",
+			"blog.title":     "is required",
+		}},
+		{"blank title", with(func(b *blogpb.Blog) { b.Title = " \t" }), nil, map[string]string{
+			"blog.title": "is required",
+		}},
+		{"long title", with(func(b *blogpb.Blog) { b.Title = strings.Repeat("x", 201) }), nil, map[string]string{
+			"blog.title": "must be at most 200 characters",
+		}},
+		{"title length in runes", with(func(b *blogpb.Blog) { b.Title = strings.Repeat("é", 200) }), nil, nil},
+		{"long content", with(func(b *blogpb.Blog) { b.Content = strings.Repeat("x", 50001) }), nil, map[string]string{
+			"blog.content": "must be at most 50000 characters",
+		}},
+		{"author pattern", with(func(b *blogpb.Blog) { b.AutherId = "ann lee" }), nil, map[string]string{
+			"blog.auther_id": "must match [A-Za-z0-9_.@-]*",
+		}},
+		{"several fields", with(func(b *blogpb.Blog) {
+			b.AutherId = strings.Repeat("a", 65)
+			b.Title = ""
+		}), nil, map[string]string{
+			"blog.auther_id": "must be at most 64 characters",
+			"blog.title":     "is required",
+		}},
+		{"only masked fields", &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Title: strings.Repeat("x", 201)}},
+			map[string]bool{"blog": true, "blog.title": true}, map[string]string{
+				"blog.title": "must be at most 200 characters",
+			}},
+		{"optional field", &blogpb.ListBlogRequest{}, nil, nil},
+		{"list author", &blogpb.ListBlogRequest{AutherId: "a/b"}, nil, map[string]string{
+			"auther_id": "must match [A-Za-z0-9_.@-]*",
+		}},
+		{"search query", &blogpb.SearchBlogsRequest{Query: strings.Repeat("q", 257)}, nil, map[string]string{
+			"query": "must be at most 256 characters",
+		}},
+	}
+	for _, tt := range tests {
+		err := validate(tt.msg, tt.only)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("%s: got %v, want no error", tt.name, err)
+			}
+			continue
+		}
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("%s: got code %v (%v), want InvalidArgument", tt.name, st.Code(), err)
+			continue
+		}
+		got := make(map[string]string)
+		for _, detail := range st.Details() {
+			if br, ok := detail.(*errdetails.BadRequest); ok {
+				for _, v := range br.GetFieldViolations() {
+					got[v.GetField()] = v.GetDescription()
+				}
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got violations %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	err := validate(&blogpb.CreateBlogRequest{Blog: &blogpb.Blog{}}, nil)
+	want := "Invalid blog.auther_id: is required (and 1 more)"
+	if msg := status.Convert(err).Message(); msg != want {
+		t.Errorf("message = %q, want %q", msg, want)
+	}
+}
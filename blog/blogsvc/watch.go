@@ -0,0 +1,245 @@
+package blogsvc
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// watchHistorySize is how many past events a reconnecting watcher can
+	// resume from.
+	watchHistorySize = 1024
+	// watchBufferSize is how many events a watcher may lag behind before
+	// it is cut off and has to resume.
+	watchBufferSize = 64
+)
+
+var (
+	errStaleResumeToken   = errors.New("resume token is from an earlier server run")
+	errExpiredResumeToken = errors.New("resume token is too old")
+	errWatchClosed        = errors.New("server is shutting down")
+)
+
+// watchHub fans blog changes out to WatchBlogs streams. It remembers the
+// latest events so a watcher can reconnect without missing any.
+type watchHub struct {
+	mu sync.Mutex
+	// epoch tells tokens of this process apart from those of earlier runs,
+	// whose sequence numbers mean nothing here.
+	epoch    string
+	seq      uint64
+	history  []hubEvent
+	watchers map[*watcher]struct{}
+	// closed is set once the server shuts down; no new watchers are taken.
+	closed bool
+}
+
+// hubEvent is a published event together with the author the blog had
+// before the change, whose watchers see the blog leave.
+type hubEvent struct {
+	event        *blogpb.BlogEvent
+	prevAuthorID string
+}
+
+// watcher is a single WatchBlogs stream. Its channel is closed when it
+// falls too far behind.
+type watcher struct {
+	authorID string
+	events   chan *blogpb.BlogEvent
+}
+
+func newWatchHub() *watchHub {
+	return &watchHub{
+		epoch:    strconv.FormatInt(time.Now().UnixNano(), 36),
+		watchers: make(map[*watcher]struct{}),
+	}
+}
+
+// publish records a change to item and hands it to every matching watcher.
+func (h *watchHub) publish(typ blogpb.BlogEvent_Type, item *blogItem) {
+	h.publishMove(typ, item, item.AuthorID)
+}
+
+// publishMove is publish for a change that may have moved item away from
+// prevAuthorID, so that watchers of either author see it.
+func (h *watchHub) publishMove(typ blogpb.BlogEvent_Type, item *blogItem, prevAuthorID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.seq++
+	he := hubEvent{
+		event: &blogpb.BlogEvent{
+			Type:        typ,
+			Blog:        item.toBlog(),
+			ResumeToken: h.token(h.seq),
+			Time:        timestamppb.Now(),
+		},
+		prevAuthorID: prevAuthorID,
+	}
+	h.history = append(h.history, he)
+	if len(h.history) > watchHistorySize {
+		h.history = h.history[len(h.history)-watchHistorySize:]
+	}
+	for w := range h.watchers {
+		if !w.matches(he) {
+			continue
+		}
+		select {
+		case w.events <- he.event:
+		default:
+			close(w.events)
+			delete(h.watchers, w)
+		}
+	}
+}
+
+// subscribe registers a watcher and returns the past events after
+// resumeToken that it should see first.
+func (h *watchHub) subscribe(authorID, resumeToken string) (*watcher, []*blogpb.BlogEvent, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, nil, errWatchClosed
+	}
+	w := &watcher{
+		authorID: authorID,
+		events:   make(chan *blogpb.BlogEvent, watchBufferSize),
+	}
+	var backlog []*blogpb.BlogEvent
+	if resumeToken != "" {
+		after, err := h.parseToken(resumeToken)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Sequence numbers in history are consecutive and end at h.seq.
+		first := h.seq - uint64(len(h.history)) + 1
+		if after+1 < first {
+			return nil, nil, errExpiredResumeToken
+		}
+		for _, he := range h.history[after+1-first:] {
+			if w.matches(he) {
+				backlog = append(backlog, he.event)
+			}
+		}
+	}
+	h.watchers[w] = struct{}{}
+	return w, backlog, nil
+}
+
+func (h *watchHub) unsubscribe(w *watcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.watchers, w)
+}
+
+// close ends every watch so the server can stop without waiting for
+// streams that never finish on their own.
+func (h *watchHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for w := range h.watchers {
+		close(w.events)
+		delete(h.watchers, w)
+	}
+}
+
+func (h *watchHub) isClosed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.closed
+}
+
+func (h *watchHub) token(seq uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(h.epoch + ":" + strconv.FormatUint(seq, 10)))
+}
+
+func (h *watchHub) parseToken(token string) (uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.New("invalid resume token")
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return 0, errors.New("invalid resume token")
+	}
+	seq, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid resume token")
+	}
+	if parts[0] != h.epoch || seq > h.seq {
+		return 0, errStaleResumeToken
+	}
+	return seq, nil
+}
+
+// matches reports whether w watches the author the blog of he has, or
+// had before the change.
+func (w *watcher) matches(he hubEvent) bool {
+	return w.authorID == "" || he.event.GetBlog().GetAutherId() == w.authorID || he.prevAuthorID == w.authorID
+}
+
+// withoutContent returns event with the blog's content left out. Only
+// admins get to read deleted blogs, so other watchers learn of deletions
+// this way.
+func withoutContent(event *blogpb.BlogEvent) *blogpb.BlogEvent {
+	event = proto.Clone(event).(*blogpb.BlogEvent)
+	event.GetBlog().Content = ""
+	return event
+}
+
+func (s *server) WatchBlogs(req *blogpb.WatchBlogsRequest, stream blogpb.BlogService_WatchBlogsServer) error {
+	if err := validate(req, nil); err != nil {
+		return err
+	}
+	w, backlog, err := s.events.subscribe(req.GetAutherId(), req.GetResumeToken())
+	if err == errStaleResumeToken || err == errExpiredResumeToken {
+		return status.Errorf(codes.OutOfRange, "Cannot resume watch: %v", err)
+	}
+	if err == errWatchClosed {
+		return status.Errorf(codes.Unavailable, "Cannot watch: %v", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, fmt.Sprint(err))
+	}
+	defer s.events.unsubscribe(w)
+
+	admin := s.adminAllowed(stream.Context()) == nil
+	send := func(event *blogpb.BlogEvent) error {
+		if event.GetType() == blogpb.BlogEvent_DELETED && !admin {
+			event = withoutContent(event)
+		}
+		return stream.Send(event)
+	}
+	for _, event := range backlog {
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case event, ok := <-w.events:
+			if !ok && s.events.isClosed() {
+				return status.Errorf(codes.Unavailable, "Server is shutting down, resume from the last resume_token")
+			}
+			if !ok {
+				return status.Errorf(codes.Aborted, "Watcher fell behind, resume from the last resume_token")
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
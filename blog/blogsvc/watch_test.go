@@ -0,0 +1,234 @@
+package blogsvc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// publishN publishes n events for blogs by author and returns their
+// resume tokens.
+func publishN(h *watchHub, n int, author string) []string {
+	var tokens []string
+	for i := 0; i < n; i++ {
+		h.publish(blogpb.BlogEvent_CREATED, &blogItem{ID: primitive.NewObjectID(), AuthorID: author})
+		tokens = append(tokens, h.token(h.seq))
+	}
+	return tokens
+}
+
+func TestWatchResume(t *testing.T) {
+	h := newWatchHub()
+	tokens := publishN(h, 3, "ann")
+
+	w, backlog, err := h.subscribe("", tokens[0])
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer h.unsubscribe(w)
+	if len(backlog) != 2 || backlog[0].GetResumeToken() != tokens[1] || backlog[1].GetResumeToken() != tokens[2] {
+		t.Errorf("backlog = %v, want the two events after the first", backlog)
+	}
+
+	// Resuming from the latest event replays nothing, and live events
+	// follow.
+	w2, backlog, err := h.subscribe("", tokens[2])
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer h.unsubscribe(w2)
+	if len(backlog) != 0 {
+		t.Errorf("backlog = %v, want nothing", backlog)
+	}
+	live := publishN(h, 1, "ann")
+	if event := <-w2.events; event.GetResumeToken() != live[0] {
+		t.Errorf("live event has token %q, want %q", event.GetResumeToken(), live[0])
+	}
+}
+
+func TestWatchBadResumeToken(t *testing.T) {
+	h := newWatchHub()
+	publishN(h, 2, "ann")
+	other := newWatchHub()
+	other.epoch = "other"
+	foreign := publishN(other, 1, "ann")[0]
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"earlier run", foreign, errStaleResumeToken},
+		{"from the future", h.token(h.seq + 1), errStaleResumeToken},
+	}
+	for _, tt := range tests {
+		if _, _, err := h.subscribe("", tt.token); err != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	for _, token := range []string{"%%%", "bm8tY29sb24", h.epoch} {
+		if _, _, err := h.subscribe("", token); err == nil || err == errStaleResumeToken {
+			t.Errorf("token %q: got %v, want an invalid token error", token, err)
+		}
+	}
+}
+
+func TestWatchHistoryOverflow(t *testing.T) {
+	h := newWatchHub()
+	tokens := publishN(h, watchHistorySize+2, "ann")
+
+	// The first two events have been dropped, so only resuming after the
+	// second one misses nothing.
+	if _, _, err := h.subscribe("", tokens[0]); err != errExpiredResumeToken {
+		t.Errorf("resuming after a dropped event: got %v, want %v", err, errExpiredResumeToken)
+	}
+	w, backlog, err := h.subscribe("", tokens[1])
+	if err != nil {
+		t.Fatalf("resuming after the last dropped event: %v", err)
+	}
+	defer h.unsubscribe(w)
+	if len(backlog) != watchHistorySize || backlog[0].GetResumeToken() != tokens[2] {
+		t.Errorf("backlog has %d events, want the %d kept ones", len(backlog), watchHistorySize)
+	}
+}
+
+func TestWatchSlowWatcher(t *testing.T) {
+	h := newWatchHub()
+	w, _, err := h.subscribe("", "")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	publishN(h, watchBufferSize+1, "ann")
+	received := 0
+	for range w.events {
+		received++
+	}
+	if received != watchBufferSize {
+		t.Errorf("received %d events before being cut off, want %d", received, watchBufferSize)
+	}
+}
+
+func TestWatchAuthorFilter(t *testing.T) {
+	h := newWatchHub()
+	ann, _, _ := h.subscribe("ann", "")
+	bob, _, _ := h.subscribe("bob", "")
+	defer h.unsubscribe(ann)
+	defer h.unsubscribe(bob)
+
+	item := &blogItem{ID: primitive.NewObjectID(), AuthorID: "ann"}
+	h.publish(blogpb.BlogEvent_CREATED, item)
+	moved := *item
+	moved.AuthorID = "bob"
+	h.publishMove(blogpb.BlogEvent_UPDATED, &moved, "ann")
+
+	for _, tt := range []struct {
+		w    *watcher
+		want []blogpb.BlogEvent_Type
+	}{
+		{ann, []blogpb.BlogEvent_Type{blogpb.BlogEvent_CREATED, blogpb.BlogEvent_UPDATED}},
+		{bob, []blogpb.BlogEvent_Type{blogpb.BlogEvent_UPDATED}},
+	} {
+		if got := len(tt.w.events); got != len(tt.want) {
+			t.Errorf("watcher of %s got %d events, want %d", tt.w.authorID, got, len(tt.want))
+			continue
+		}
+		for _, typ := range tt.want {
+			if event := <-tt.w.events; event.GetType() != typ {
+				t.Errorf("watcher of %s got a %v event, want %v", tt.w.authorID, event.GetType(), typ)
+			}
+		}
+	}
+}
+
+// watchStream collects the events WatchBlogs sends and ends the call
+// once it has want of them.
+type watchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	want   int
+	events []*blogpb.BlogEvent
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(event *blogpb.BlogEvent) error {
+	s.events = append(s.events, event)
+	if len(s.events) == s.want {
+		s.cancel()
+	}
+	return nil
+}
+
+// watch replays the events after resumeToken through WatchBlogs as the
+// caller of ctx sees them.
+func watch(t *testing.T, ctx context.Context, s *server, req *blogpb.WatchBlogsRequest, want int) []*blogpb.BlogEvent {
+	t.Helper()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream := &watchStream{ctx: ctx, cancel: cancel, want: want}
+	err := s.WatchBlogs(req, stream)
+	wantCode(t, err, codes.Canceled)
+	return stream.events
+}
+
+func TestWatchBlogsDeletedContent(t *testing.T) {
+	s := newTestServer()
+	s.authEnabled = true
+	admin := auth.NewContext(context.Background(), auth.Identity{Subject: "root", Roles: []string{auth.AdminRole}})
+	ann := auth.NewContext(context.Background(), auth.Identity{Subject: "ann"})
+
+	blog := mustCreate(t, ann, s, "ann", "Title", "Secret")
+	start := s.events.token(s.events.seq)
+	if _, err := s.DeleteBlog(ann, &blogpb.DeleteBlogRequest{BlogId: blog.GetId()}); err != nil {
+		t.Fatalf("DeleteBlog: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantContent string
+	}{
+		{"anonymous", context.Background(), ""},
+		{"author", ann, ""},
+		{"admin", admin, "Secret"},
+	}
+	for _, tt := range tests {
+		events := watch(t, tt.ctx, s, &blogpb.WatchBlogsRequest{ResumeToken: start}, 1)
+		if len(events) != 1 || events[0].GetType() != blogpb.BlogEvent_DELETED {
+			t.Fatalf("%s: got %v, want the DELETED event", tt.name, events)
+		}
+		if got := events[0].GetBlog(); got.GetContent() != tt.wantContent || got.GetTitle() != "Title" {
+			t.Errorf("%s: got blog %v, want content %q", tt.name, got, tt.wantContent)
+		}
+	}
+	// The stored event keeps its content for later watchers.
+	if events := watch(t, admin, s, &blogpb.WatchBlogsRequest{ResumeToken: start}, 1); events[0].GetBlog().GetContent() != "Secret" {
+		t.Errorf("content was stripped from the history")
+	}
+}
+
+func TestWatchBlogsMovedAway(t *testing.T) {
+	s := newTestServer()
+	blog := mustCreate(t, context.Background(), s, "ann", "Title", "")
+	start := s.events.token(s.events.seq)
+	_, err := s.UpdateBlog(context.Background(), &blogpb.UpdateBlogRequest{
+		Blog:       &blogpb.Blog{Id: blog.GetId(), AutherId: "bob"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"auther_id"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateBlog: %v", err)
+	}
+	events := watch(t, context.Background(), s, &blogpb.WatchBlogsRequest{AutherId: "ann", ResumeToken: start}, 1)
+	if len(events) != 1 || events[0].GetBlog().GetAutherId() != "bob" {
+		t.Errorf("watcher of the old author got %v, want the update moving the blog to bob", events)
+	}
+}
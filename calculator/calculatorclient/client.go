@@ -0,0 +1,92 @@
+// Package calculatorclient is a typed client of the CalculatorService.
+package calculatorclient
+
+import (
+	"context"
+
+	"github.com/shivkumar123g/grpc_go_course/calculator/calculatorpb"
+	"github.com/shivkumar123g/grpc_go_course/client"
+	"google.golang.org/grpc"
+)
+
+// Client calls the CalculatorService. Its errors are *client.Error
+// values.
+type Client struct {
+	cc  *grpc.ClientConn
+	rpc calculatorpb.CalculatorServiceClient
+}
+
+// New returns a Client using cc, which the caller keeps ownership of.
+func New(cc grpc.ClientConnInterface) *Client {
+	return &Client{rpc: calculatorpb.NewCalculatorServiceClient(cc)}
+}
+
+// Dial connects to the server at target.
+func Dial(ctx context.Context, target string, opts client.Options) (*Client, error) {
+	cc, err := client.Dial(ctx, target, opts)
+	if err != nil {
+		return nil, err
+	}
+	c := New(cc)
+	c.cc = cc
+	return c, nil
+}
+
+// Close closes the connection Dial opened.
+func (c *Client) Close() error {
+	if c.cc == nil {
+		return nil
+	}
+	return c.cc.Close()
+}
+
+// Sum returns a + b.
+func (c *Client) Sum(ctx context.Context, a, b int32) (int32, error) {
+	res, err := c.rpc.Sum(ctx, &calculatorpb.SumRequest{FistNumber: a, SecondNumber: b})
+	if err != nil {
+		return 0, client.Convert(err)
+	}
+	return res.GetSumResult(), nil
+}
+
+// PrimeFactors iterates over the prime factors of n, smallest first.
+func (c *Client) PrimeFactors(ctx context.Context, n int64) *client.Iterator[int64] {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.rpc.PrimeNumberDecompsition(ctx, &calculatorpb.PrimeNumberDecompsitionRequest{Number: n})
+	if err != nil {
+		return client.Failed[int64](err, cancel)
+	}
+	return client.NewIterator(func() (int64, error) {
+		res, err := stream.Recv()
+		return res.GetPrimeFactor(), err
+	}, cancel)
+}
+
+// Average returns the mean of numbers.
+func (c *Client) Average(ctx context.Context, numbers []int64) (float64, error) {
+	stream, err := c.rpc.ComputeAverage(ctx)
+	if err != nil {
+		return 0, client.Convert(err)
+	}
+	for _, n := range numbers {
+		if err := stream.Send(&calculatorpb.ComputeAverageRequest{Number: n}); err != nil {
+			// The server ended the call; its status comes with the response.
+			break
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, client.Convert(err)
+	}
+	return res.GetNumber(), nil
+}
+
+// SquareRoot returns the square root of n, failing with
+// client.ErrInvalidArgument for negative numbers.
+func (c *Client) SquareRoot(ctx context.Context, n int32) (float64, error) {
+	res, err := c.rpc.SquareRoot(ctx, &calculatorpb.SquareRootRequest{Number: n})
+	if err != nil {
+		return 0, client.Convert(err)
+	}
+	return res.GetNumberRoot(), nil
+}
@@ -0,0 +1,79 @@
+// Package calculatorsvc implements the CalculatorService.
+package calculatorsvc
+
+import (
+	"context"
+	"io"
+	"math"
+
+	"github.com/shivkumar123g/grpc_go_course/calculator/calculatorpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type server struct{}
+
+func (*server) Sum(ctx context.Context, req *calculatorpb.SumRequest) (*calculatorpb.SumResponse, error) {
+	a := req.GetFistNumber()
+	b := req.GetSecondNumber()
+	c := a + b
+	return &calculatorpb.SumResponse{
+		SumResult: c,
+	}, nil
+}
+
+func (*server) PrimeNumberDecompsition(req *calculatorpb.PrimeNumberDecompsitionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompsitionServer) error {
+	n := req.GetNumber()
+	k := int64(2)
+	for n > 1 {
+		if n%k == 0 {
+			err := stream.Send(&calculatorpb.PrimeNumberDecompsitionResponse{
+				PrimeFactor: k,
+			})
+			if err != nil {
+				return err
+			}
+			n = n / k
+
+		} else {
+			k++
+		}
+	}
+	return nil
+}
+
+func (*server) ComputeAverage(stream calculatorpb.CalculatorService_ComputeAverageServer) error {
+	v := int64(0)
+	n := int64(0)
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(
+				&calculatorpb.ComputeAverageResponse{
+					Number: float64(v) / float64(n),
+				},
+			)
+		}
+		if err != nil {
+			return err
+		}
+		v += req.GetNumber()
+		n++
+	}
+}
+
+func (*server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootRequest) (*calculatorpb.SquareRootResponse, error) {
+	number := req.GetNumber()
+	if number < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Received a negative number: %v", number)
+	}
+	return &calculatorpb.SquareRootResponse{
+		NumberRoot: math.Sqrt(float64(number)),
+	}, nil
+}
+
+// Register adds the CalculatorService to s.
+func Register(s *grpc.Server) {
+	calculatorpb.RegisterCalculatorServiceServer(s, &server{})
+}
@@ -0,0 +1,201 @@
+// Package certs sets up TLS for servers and clients from certificate
+// files, reloading them when they change on disk so that rotated
+// certificates are picked up without a restart. With a client CA, servers
+// require mutual TLS and handlers can read the caller's certificate
+// subject with ClientSubject.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/config"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// checkInterval is how often the files are checked for changes, at most.
+const checkInterval = time.Second
+
+// source holds a key pair and a CA pool read from files, either of which
+// may be absent, and reloads them when the files' modification times
+// change.
+type source struct {
+	certFile, keyFile, caFile string
+
+	mu      sync.Mutex
+	checked time.Time
+	stamps  []time.Time
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+// newSource reads the files, failing if any of them cannot be used.
+func newSource(certFile, keyFile, caFile string) (*source, error) {
+	s := &source{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	stamps, err := s.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	s.stamps, s.checked = stamps, time.Now()
+	return s, nil
+}
+
+func (s *source) files() []string {
+	var files []string
+	for _, f := range []string{s.certFile, s.keyFile, s.caFile} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+func (s *source) stat() ([]time.Time, error) {
+	var stamps []time.Time
+	for _, f := range s.files() {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		stamps = append(stamps, fi.ModTime())
+	}
+	return stamps, nil
+}
+
+// load reads the files, replacing the current key pair and pool only if
+// all of them are valid.
+func (s *source) load() error {
+	var cert *tls.Certificate
+	if s.certFile != "" {
+		c, err := tls.LoadX509KeyPair(s.certFile, s.keyFile)
+		if err != nil {
+			return fmt.Errorf("loading key pair: %v", err)
+		}
+		cert = &c
+	}
+	var pool *x509.CertPool
+	if s.caFile != "" {
+		data, err := ioutil.ReadFile(s.caFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificates found in %s", s.caFile)
+		}
+	}
+	s.cert, s.pool = cert, pool
+	return nil
+}
+
+// get returns the current key pair and pool, reloading them first if the
+// files changed. Files that fail to load, for instance because they are
+// halfway through being replaced, leave the previous ones in use and are
+// tried again on the next check.
+func (s *source) get() (*tls.Certificate, *x509.CertPool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.checked) < checkInterval {
+		return s.cert, s.pool
+	}
+	s.checked = time.Now()
+	stamps, err := s.stat()
+	if err == nil && sameStamps(stamps, s.stamps) {
+		return s.cert, s.pool
+	}
+	if err == nil {
+		err = s.load()
+	}
+	if err != nil {
+		slog.Warn("Failed reloading TLS certificates, keeping the previous ones", "files", s.files(), "error", err)
+		return s.cert, s.pool
+	}
+	s.stamps = stamps
+	slog.Info("Reloaded TLS certificates", "files", s.files())
+	return s.cert, s.pool
+}
+
+func sameStamps(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ServerCredentials returns the transport credentials of a server
+// configured by cfg. When cfg.ClientCAFile is set, clients must present a
+// certificate it verifies.
+func ServerCredentials(cfg config.TLS) (credentials.TransportCredentials, error) {
+	src, err := newSource(cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool := src.get()
+			c := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				NextProtos:   []string{"h2"},
+			}
+			if pool != nil {
+				c.ClientAuth = tls.RequireAndVerifyClientCert
+				c.ClientCAs = pool
+			}
+			return c, nil
+		},
+	}), nil
+}
+
+// ClientCredentials returns the transport credentials of a client that
+// trusts the servers caFile verifies and, if certFile is set, presents
+// that certificate for mutual TLS. An empty caFile trusts the system
+// roots. serverName overrides the name checked against the server
+// certificate when set. Only the client certificate is reloaded; the CA
+// is read once.
+func ClientCredentials(caFile, certFile, keyFile, serverName string) (credentials.TransportCredentials, error) {
+	src, err := newSource(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	c := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: serverName, RootCAs: src.pool}
+	if certFile != "" {
+		c.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := src.get()
+			return cert, nil
+		}
+	}
+	return credentials.NewTLS(c), nil
+}
+
+// ClientSubject returns the subject of the certificate the caller of the
+// current call presented and the server verified, if any.
+func ClientSubject(ctx context.Context) (pkix.Name, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return pkix.Name{}, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return pkix.Name{}, false
+	}
+	return info.State.VerifiedChains[0][0].Subject, true
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/certs"
+)
+
+const day = 24 * time.Hour
+
+// gen_certs creates a local CA and issues server and client certificates
+// from it, for development. Run without arguments it does all three in
+// the ssl directory, reusing the CA if there is one:
+//
+//	go run ./certs/gen_certs
+//
+// The files are the ones the servers and clients look for by default:
+// ca.crt and ca.key, server.crt and server.pem, client.crt and client.pem.
+// Start a server with -tls (and -tls-client-ca ssl/ca.crt for mutual TLS)
+// and point clients at ssl/ca.crt, e.g.
+//
+//	grpcctl greet hello Ann -ca ssl/ca.crt -cert ssl/client.crt -key ssl/client.pem
+//
+// "ca", "server" or "client" runs a single step; issuing again replaces
+// the certificate, which running servers pick up by themselves. A CA key
+// that is encrypted, like the one of the old openssl script, is replaced
+// by a new CA. A replaced CA is kept as ca.crt.old and ca.key.old.
+func main() {
+	dir := flag.String("dir", "ssl", "directory of the CA and the certificates")
+	force := flag.Bool("force", false, "replace an existing CA")
+	caName := flag.String("ca-name", "grpc_go_course development CA", "common name of the CA")
+	caDays := flag.Int("ca-days", 3650, "lifetime of the CA in days")
+	days := flag.Int("days", 365, "lifetime of server and client certificates in days")
+	serverName := flag.String("server-name", "", "common name of the server certificate; the first SAN when empty")
+	serverSANs := flag.String("sans", "localhost,127.0.0.1", "comma-separated DNS names and IP addresses of the server")
+	serverFile := flag.String("server-file", "server", "base name of the server certificate files")
+	clientName := flag.String("client-name", "dev-client", "common name of the client certificate, the caller identity servers see")
+	clientSANs := flag.String("client-sans", "", "comma-separated SANs of the client certificate")
+	clientFile := flag.String("client-file", "client", "base name of the client certificate files")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [all|ca|server|client]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Flags may follow the step too.
+	step := "all"
+	if flag.NArg() > 0 {
+		step = flag.Arg(0)
+		flag.CommandLine.Parse(flag.Args()[1:])
+		if flag.NArg() > 0 {
+			flag.Usage()
+			os.Exit(2)
+		}
+	}
+	if step != "all" && step != "ca" && step != "server" && step != "client" {
+		log.Fatalf("Unknown step %q, want all, ca, server or client", step)
+	}
+	if *caDays <= 0 || *days <= 0 {
+		log.Fatal("-ca-days and -days must be positive")
+	}
+	if err := os.MkdirAll(*dir, 0755); err != nil {
+		log.Fatalf("Failed creating %s: %v", *dir, err)
+	}
+
+	caCert, caKey := filepath.Join(*dir, "ca.crt"), filepath.Join(*dir, "ca.key")
+	// newCA creates the CA, keeping the files of the one it replaces as
+	// ca.crt.old and ca.key.old.
+	newCA := func() *certs.Authority {
+		for _, f := range []string{caCert, caKey} {
+			if err := os.Rename(f, f+".old"); err == nil {
+				log.Printf("Moved %s to %s", f, f+".old")
+			} else if !os.IsNotExist(err) {
+				log.Fatalf("Failed moving %s aside: %v", f, err)
+			}
+		}
+		ca, err := certs.NewAuthority(*caName, time.Duration(*caDays)*day)
+		if err != nil {
+			log.Fatalf("Failed creating the CA: %v", err)
+		}
+		certPEM, keyPEM, err := ca.PEM()
+		if err != nil {
+			log.Fatalf("Failed encoding the CA: %v", err)
+		}
+		write(caCert, certPEM, caKey, keyPEM)
+		return ca
+	}
+	var ca *certs.Authority
+	_, statErr := os.Stat(caKey)
+	exists := statErr == nil
+	switch {
+	case step == "ca" && exists && !*force:
+		log.Fatalf("%s already exists; use -force to replace the CA and invalidate its certificates", caKey)
+	case step == "ca" || *force || !exists:
+		ca = newCA()
+	default:
+		var err error
+		ca, err = certs.LoadAuthority(caCert, caKey)
+		if errors.Is(err, certs.ErrEncryptedKey) {
+			// The passphrase protected CA of the old openssl script is
+			// of no use to this tool, so it makes way for a new one.
+			log.Printf("Cannot use the CA in %s: %v; creating a new one", *dir, err)
+			ca = newCA()
+		} else if err != nil {
+			log.Fatalf("Failed loading the CA: %v (create a new one with -force)", err)
+		}
+	}
+
+	if step == "all" || step == "server" {
+		certPEM, keyPEM, err := ca.Issue(*serverName, split(*serverSANs), time.Duration(*days)*day, certs.ServerUsage)
+		if err != nil {
+			log.Fatalf("Failed issuing the server certificate: %v", err)
+		}
+		write(filepath.Join(*dir, *serverFile+".crt"), certPEM, filepath.Join(*dir, *serverFile+".pem"), keyPEM)
+	}
+	if step == "all" || step == "client" {
+		certPEM, keyPEM, err := ca.Issue(*clientName, split(*clientSANs), time.Duration(*days)*day, certs.ClientUsage)
+		if err != nil {
+			log.Fatalf("Failed issuing the client certificate: %v", err)
+		}
+		write(filepath.Join(*dir, *clientFile+".crt"), certPEM, filepath.Join(*dir, *clientFile+".pem"), keyPEM)
+	}
+}
+
+// write saves a certificate and its key, the key readable only by the
+// owner. Each file is replaced in one step, so that servers reloading
+// them never read half a file.
+func write(certFile string, certPEM []byte, keyFile string, keyPEM []byte) {
+	for _, f := range []struct {
+		name string
+		data []byte
+		perm os.FileMode
+	}{{keyFile, keyPEM, 0600}, {certFile, certPEM, 0644}} {
+		tmp := f.name + ".tmp"
+		if err := ioutil.WriteFile(tmp, f.data, f.perm); err != nil {
+			log.Fatalf("Failed writing %s: %v", f.name, err)
+		}
+		if err := os.Rename(tmp, f.name); err != nil {
+			log.Fatalf("Failed writing %s: %v", f.name, err)
+		}
+		log.Printf("Wrote %s", f.name)
+	}
+}
+
+func split(list string) []string {
+	var out []string
+	for _, s := range strings.Split(list, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,203 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+)
+
+// Authority is a certificate authority issuing certificates for local
+// development and tests.
+type Authority struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+}
+
+// Usages of issued certificates.
+const (
+	ServerUsage = x509.ExtKeyUsageServerAuth
+	ClientUsage = x509.ExtKeyUsageClientAuth
+)
+
+// ErrEncryptedKey is the error LoadAuthority returns for a passphrase
+// protected key, such as those of the openssl instructions, which it
+// cannot read.
+var ErrEncryptedKey = errors.New("key is encrypted")
+
+// backdate makes certificates valid a little before they are issued, to
+// allow for clock skew between machines.
+const backdate = 5 * time.Minute
+
+func newKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+func newSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// NewAuthority creates a self-signed CA called name, valid for validity.
+func NewAuthority(name string, validity time.Duration) (*Authority, error) {
+	key, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             now.Add(-backdate),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Authority{Cert: cert, Key: key}, nil
+}
+
+// LoadAuthority reads a CA written by PEM. Encrypted keys are not
+// supported; they fail with an error matching ErrEncryptedKey.
+func LoadAuthority(certFile, keyFile string) (*Authority, error) {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no certificate found in %s", certFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("%s is not a CA certificate", certFile)
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	block, _ = pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no key found in %s", keyFile)
+	}
+	if block.Headers["Proc-Type"] != "" || block.Type == "ENCRYPTED PRIVATE KEY" {
+		return nil, fmt.Errorf("%s: %w", keyFile, ErrEncryptedKey)
+	}
+	key, err := parseKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", keyFile, err)
+	}
+	return &Authority{Cert: cert, Key: key}, nil
+}
+
+func parseKey(block *pem.Block) (crypto.Signer, error) {
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("key cannot sign")
+	}
+	return signer, nil
+}
+
+// PEM returns the CA certificate and key in the form LoadAuthority reads.
+func (a *Authority) PEM() (certPEM, keyPEM []byte, err error) {
+	return encode(a.Cert.Raw, a.Key)
+}
+
+// Issue creates a certificate for name signed by the CA, valid for
+// validity and for usage. Each SAN is an IP address, an email address or
+// a DNS name; the first SAN is used when name is empty. The result is PEM
+// encoded, the key as PKCS #8 like the server key gRPC examples use.
+func (a *Authority) Issue(name string, sans []string, validity time.Duration, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte, err error) {
+	if name == "" && len(sans) > 0 {
+		name = sans[0]
+	}
+	if name == "" {
+		return nil, nil, errors.New("a certificate needs a name or a SAN")
+	}
+	key, err := newKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             now.Add(-backdate),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{usage},
+		BasicConstraintsValid: true,
+	}
+	if tmpl.NotAfter.After(a.Cert.NotAfter) {
+		return nil, nil, fmt.Errorf("certificate would outlive the CA, which expires %v", a.Cert.NotAfter.Format(time.RFC3339))
+	}
+	for _, san := range sans {
+		switch ip := net.ParseIP(san); {
+		case ip != nil:
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		case strings.Contains(san, "@"):
+			tmpl.EmailAddresses = append(tmpl.EmailAddresses, san)
+		default:
+			tmpl.DNSNames = append(tmpl.DNSNames, san)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, a.Cert, key.Public(), a.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encode(der, key)
+}
+
+func encode(der []byte, key crypto.Signer) (certPEM, keyPEM []byte, err error) {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
@@ -0,0 +1,149 @@
+// Package client holds what the typed clients of the services share:
+// dialing with TLS, bearer tokens and timeouts, errors mapped from status
+// codes, and iterators over server streams. The clients themselves are
+// greetclient, calculatorclient and blogclient.
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"github.com/shivkumar123g/grpc_go_course/certs"
+	"github.com/shivkumar123g/grpc_go_course/metrics"
+	"github.com/shivkumar123g/grpc_go_course/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+)
+
+// Options configure a connection. The zero value connects without TLS,
+// token or timeouts.
+type Options struct {
+	// TLS, when set, secures the connection.
+	TLS *TLS
+	// Token is sent as a bearer token with every call when set, see
+	// package auth.
+	Token string
+	// Timeout bounds unary calls whose context has no deadline. Streams
+	// are left alone, as they may rightly run for long.
+	Timeout time.Duration
+	// DialTimeout, when set, makes Dial wait this long at most for the
+	// connection to be up, instead of connecting in the background.
+	DialTimeout time.Duration
+	// Retry is how failed calls are retried and slow reads hedged.
+	Retry RetryPolicy
+	// WaitForReady makes calls wait, up to their deadline, while the
+	// server cannot be reached, e.g. when it is restarting. Otherwise they
+	// fail with UNAVAILABLE right away, as retries only cover calls that
+	// reached a server.
+	WaitForReady bool
+	// Metrics, when set, records the calls made, see package metrics.
+	// Connections may share it, as its collectors are registered once.
+	Metrics *metrics.ClientMetrics
+	// Extra dial options, added after the ones Options produce.
+	Extra []grpc.DialOption
+}
+
+// TLS configures the client side of TLS, see certs.ClientCredentials.
+type TLS struct {
+	// CAFile verifies the server; the system roots do when empty.
+	CAFile string
+	// CertFile and KeyFile are the client certificate for servers that
+	// require mutual TLS.
+	CertFile, KeyFile string
+	// ServerName overrides the name the server certificate must match.
+	ServerName string
+}
+
+// DialOptions returns the gRPC dial options opts stand for, including
+// tracing and metrics.
+func (opts Options) DialOptions() ([]grpc.DialOption, error) {
+	var dialOpts []grpc.DialOption
+	if opts.TLS != nil {
+		creds, err := certs.ClientCredentials(opts.TLS.CAFile, opts.TLS.CertFile, opts.TLS.KeyFile, opts.TLS.ServerName)
+		if err != nil {
+			return nil, fmt.Errorf("loading certificates: %v", err)
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+	if opts.Token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(auth.TokenCredentials{Token: opts.Token, Insecure: opts.TLS == nil}))
+	}
+	// Tracing comes first, so that the hedges of a call are part of its
+	// trace; the timeout covers them all.
+	dialOpts = append(dialOpts, tracing.DialOptions()...)
+	// Metrics count a call once however often it is retried or hedged,
+	// and time it as its caller waits for it.
+	if opts.Metrics != nil {
+		dialOpts = append(dialOpts,
+			grpc.WithChainUnaryInterceptor(opts.Metrics.UnaryClientInterceptor()),
+			grpc.WithChainStreamInterceptor(opts.Metrics.StreamClientInterceptor()),
+		)
+	}
+	if opts.Timeout > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(timeoutInterceptor(opts.Timeout)))
+	}
+	serviceConfig, err := opts.Retry.ServiceConfig()
+	if err != nil {
+		return nil, err
+	}
+	dialOpts = append(dialOpts,
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		// Reconnect soon after the server went away, so that retries can
+		// get through to it once it is back.
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: reconnectBackoff, MinConnectTimeout: 20 * time.Second}),
+		grpc.WithChainUnaryInterceptor(idempotencyInterceptor()),
+	)
+	if retry := opts.Retry.withDefaults(); retry.hedged() {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(hedgingInterceptor(retry)))
+	}
+	if opts.WaitForReady {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.WaitForReady(true)))
+	}
+	if opts.DialTimeout > 0 {
+		dialOpts = append(dialOpts, grpc.WithBlock())
+	}
+	return append(dialOpts, opts.Extra...), nil
+}
+
+// reconnectBackoff spaces the attempts to reconnect to a server.
+var reconnectBackoff = backoff.Config{
+	BaseDelay:  100 * time.Millisecond,
+	Multiplier: 1.6,
+	Jitter:     0.2,
+	MaxDelay:   5 * time.Second,
+}
+
+// Dial connects to target, a "host:port" address or any target gRPC
+// resolves.
+func Dial(ctx context.Context, target string, opts Options) (*grpc.ClientConn, error) {
+	dialOpts, err := opts.DialOptions()
+	if err != nil {
+		return nil, err
+	}
+	if opts.DialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.DialTimeout)
+		defer cancel()
+	}
+	cc, err := grpc.DialContext(ctx, target, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %v", target, err)
+	}
+	return cc, nil
+}
+
+// timeoutInterceptor gives unary calls without a deadline one.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
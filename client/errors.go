@@ -0,0 +1,124 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors the status codes map to. Test for them with errors.Is:
+//
+//	if errors.Is(err, client.ErrNotFound) { ... }
+var (
+	ErrCanceled           = errors.New("canceled")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrDeadlineExceeded   = errors.New("deadline exceeded")
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrResourceExhausted  = errors.New("resource exhausted")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	// ErrConflict is ABORTED, e.g. a blog modified concurrently.
+	ErrConflict = errors.New("conflict")
+	// ErrOutOfRange is OUT_OF_RANGE, e.g. a watch resume token the server
+	// no longer has the events after.
+	ErrOutOfRange      = errors.New("out of range")
+	ErrUnimplemented   = errors.New("unimplemented")
+	ErrUnavailable     = errors.New("unavailable")
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrInternal covers the remaining server failures: UNKNOWN, INTERNAL
+	// and DATA_LOSS.
+	ErrInternal = errors.New("internal error")
+)
+
+var codeErrors = map[codes.Code]error{
+	codes.Canceled:           ErrCanceled,
+	codes.Unknown:            ErrInternal,
+	codes.InvalidArgument:    ErrInvalidArgument,
+	codes.DeadlineExceeded:   ErrDeadlineExceeded,
+	codes.NotFound:           ErrNotFound,
+	codes.AlreadyExists:      ErrAlreadyExists,
+	codes.PermissionDenied:   ErrPermissionDenied,
+	codes.ResourceExhausted:  ErrResourceExhausted,
+	codes.FailedPrecondition: ErrFailedPrecondition,
+	codes.Aborted:            ErrConflict,
+	codes.OutOfRange:         ErrOutOfRange,
+	codes.Unimplemented:      ErrUnimplemented,
+	codes.Internal:           ErrInternal,
+	codes.Unavailable:        ErrUnavailable,
+	codes.DataLoss:           ErrInternal,
+	codes.Unauthenticated:    ErrUnauthenticated,
+}
+
+// Error is a failed call. It matches the error of its code with
+// errors.Is, and status.FromError still works on it.
+type Error struct {
+	Code    codes.Code
+	Message string
+	// Violations lists the invalid fields of an InvalidArgument error.
+	Violations []Violation
+	// RetryDelay is how long the server asked the caller to wait before
+	// trying again, e.g. when rate limited.
+	RetryDelay time.Duration
+	status     *status.Status
+}
+
+// Violation is a request field that failed validation.
+type Violation struct {
+	// Field is the path of the field, e.g. "blog.title".
+	Field       string
+	Description string
+}
+
+func (e *Error) Error() string {
+	return e.Code.String() + ": " + e.Message
+}
+
+// Unwrap returns the error of e's code.
+func (e *Error) Unwrap() error {
+	return codeErrors[e.Code]
+}
+
+// GRPCStatus returns the status e was made from.
+func (e *Error) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// Convert turns the status error of a call into an *Error. nil, io.EOF
+// and errors without a status are returned as they are.
+func Convert(err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return FromStatus(st)
+}
+
+// FromStatus returns the *Error of st, or nil if st is OK.
+func FromStatus(st *status.Status) error {
+	if st.Code() == codes.OK {
+		return nil
+	}
+	e := &Error{Code: st.Code(), Message: st.Message(), status: st}
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range detail.GetFieldViolations() {
+				e.Violations = append(e.Violations, Violation{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		case *errdetails.RetryInfo:
+			e.RetryDelay = detail.GetRetryDelay().AsDuration()
+		}
+	}
+	return e
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestConvert(t *testing.T) {
+	for code := codes.Canceled; code <= codes.Unauthenticated; code++ {
+		err := Convert(status.Error(code, "failed"))
+		if want := codeErrors[code]; !errors.Is(err, want) {
+			t.Errorf("%v: got %v, want it to match %v", code, err, want)
+		}
+		if status.Code(err) != code {
+			t.Errorf("%v: status.Code gives %v", code, status.Code(err))
+		}
+	}
+	if !errors.Is(Convert(status.Error(codes.OutOfRange, "Cannot resume watch")), ErrOutOfRange) {
+		t.Errorf("OUT_OF_RANGE does not match ErrOutOfRange")
+	}
+	if err := Convert(nil); err != nil {
+		t.Errorf("Convert(nil) = %v", err)
+	}
+}
+
+func TestFromStatusDetails(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "Invalid blog").WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "blog.title", Description: "is required"},
+		}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(1500 * time.Millisecond)},
+	)
+	if err != nil {
+		t.Fatalf("WithDetails: %v", err)
+	}
+	e := FromStatus(st).(*Error)
+	if len(e.Violations) != 1 || e.Violations[0] != (Violation{Field: "blog.title", Description: "is required"}) {
+		t.Errorf("violations = %v", e.Violations)
+	}
+	if e.RetryDelay != 1500*time.Millisecond {
+		t.Errorf("retry delay = %v, want 1.5s", e.RetryDelay)
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"io"
+)
+
+// Iterator walks the messages of a server stream:
+//
+//	it := c.ListBlog(ctx, req)
+//	defer it.Close()
+//	for it.Next() {
+//		use(it.Value())
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	recv   func() (T, error)
+	cancel context.CancelFunc
+	value  T
+	err    error
+	done   bool
+}
+
+// NewIterator returns an Iterator over the values recv returns until it
+// fails. cancel ends the stream early on Close.
+func NewIterator[T any](recv func() (T, error), cancel context.CancelFunc) *Iterator[T] {
+	return &Iterator[T]{recv: recv, cancel: cancel}
+}
+
+// Failed returns an Iterator that yields nothing and reports err, for
+// streams that could not be opened.
+func Failed[T any](err error, cancel context.CancelFunc) *Iterator[T] {
+	return &Iterator[T]{err: Convert(err), cancel: cancel, done: true}
+}
+
+// Next advances to the next value, returning false at the end of the
+// stream or on error.
+func (it *Iterator[T]) Next() bool {
+	if it.done {
+		return false
+	}
+	value, err := it.recv()
+	if err != nil {
+		it.done = true
+		if err != io.EOF {
+			it.err = Convert(err)
+		}
+		it.cancel()
+		var zero T
+		it.value = zero
+		return false
+	}
+	it.value = value
+	return true
+}
+
+// Value returns the value Next advanced to.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that ended the stream, nil if it ended normally.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close ends the stream, if it is still open.
+func (it *Iterator[T]) Close() {
+	it.done = true
+	it.cancel()
+}
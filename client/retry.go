@@ -0,0 +1,355 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	mathrand "math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// IdempotencyKeyHeader is the metadata key of the idempotency key sent
+// with calls that change state. Servers apply a call once per key, so
+// that retrying it is safe.
+const IdempotencyKeyHeader = "idempotency-key"
+
+// pushbackHeader is the trailer in which servers say how many milliseconds
+// to wait before retrying.
+const pushbackHeader = "grpc-retry-pushback-ms"
+
+// readMethods only read, so they may be hedged.
+var readMethods = map[string]bool{
+	"/greet.GreetService/Greet":                true,
+	"/calculator.CalculatorService/Sum":        true,
+	"/calculator.CalculatorService/SquareRoot": true,
+	"/blog.BlogService/ReadBlog":               true,
+	"/blog.BlogService/ListBlogsPage":          true,
+	"/blog.BlogService/SearchBlogs":            true,
+	"/blog.BlogService/BatchGetBlogs":          true,
+}
+
+// writeMethods change state; each call gets an idempotency key.
+var writeMethods = map[string]bool{
+	"/blog.BlogService/CreateBlog":       true,
+	"/blog.BlogService/UpdateBlog":       true,
+	"/blog.BlogService/DeleteBlog":       true,
+	"/blog.BlogService/UndeleteBlog":     true,
+	"/blog.BlogService/BatchCreateBlogs": true,
+	"/blog.BlogService/BatchDeleteBlogs": true,
+}
+
+// retriedServices are the services the retry policy covers.
+var retriedServices = []string{"greet.GreetService", "calculator.CalculatorService", "blog.BlogService"}
+
+// RetryPolicy says how failed calls are tried again. Calls failing with
+// UNAVAILABLE or RESOURCE_EXHAUSTED are retried with exponential backoff,
+// honouring the pushback a server sends. Zero fields take the values of
+// DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt too; 1 turns retries off and
+	// gRPC allows at most 5.
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+	// HedgingDelay is how long a read waits for its response before the
+	// same call is sent again, the first response winning. Negative turns
+	// hedging off. Hedges count against MaxAttempts, which hedged reads
+	// raise to MaxHedges+1 when it is lower.
+	HedgingDelay time.Duration
+	// MaxHedges is the number of extra calls a read may send.
+	MaxHedges int
+}
+
+// DefaultRetryPolicy is the policy of clients that set none.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:       5,
+	InitialBackoff:    200 * time.Millisecond,
+	MaxBackoff:        5 * time.Second,
+	BackoffMultiplier: 2,
+	HedgingDelay:      500 * time.Millisecond,
+	MaxHedges:         1,
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	d := DefaultRetryPolicy
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = d.MaxAttempts
+	}
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = d.InitialBackoff
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = d.MaxBackoff
+	}
+	if p.BackoffMultiplier == 0 {
+		p.BackoffMultiplier = d.BackoffMultiplier
+	}
+	if p.HedgingDelay == 0 {
+		p.HedgingDelay = d.HedgingDelay
+	}
+	if p.MaxHedges == 0 {
+		p.MaxHedges = d.MaxHedges
+	}
+	return p
+}
+
+// hedged reports whether reads are hedged.
+func (p RetryPolicy) hedged() bool {
+	return p.HedgingDelay > 0 && p.MaxHedges > 0
+}
+
+// ServiceConfig returns the gRPC service config carrying the retry part
+// of p. Hedging is left to an interceptor, as grpc-go does not implement
+// hedging policies.
+func (p RetryPolicy) ServiceConfig() (string, error) {
+	p = p.withDefaults()
+	type name struct {
+		Service string `json:"service"`
+		Method  string `json:"method,omitempty"`
+	}
+	type retryPolicy struct {
+		MaxAttempts          int      `json:"maxAttempts"`
+		InitialBackoff       string   `json:"initialBackoff"`
+		MaxBackoff           string   `json:"maxBackoff"`
+		BackoffMultiplier    float64  `json:"backoffMultiplier"`
+		RetryableStatusCodes []string `json:"retryableStatusCodes"`
+	}
+	type methodConfig struct {
+		Name        []name       `json:"name"`
+		RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+	}
+	type throttling struct {
+		MaxTokens  int     `json:"maxTokens"`
+		TokenRatio float64 `json:"tokenRatio"`
+	}
+	var cfg struct {
+		MethodConfig    []methodConfig `json:"methodConfig"`
+		RetryThrottling *throttling    `json:"retryThrottling,omitempty"`
+	}
+	if p.MaxAttempts > 1 {
+		mc := methodConfig{RetryPolicy: &retryPolicy{
+			MaxAttempts:          p.MaxAttempts,
+			InitialBackoff:       seconds(p.InitialBackoff),
+			MaxBackoff:           seconds(p.MaxBackoff),
+			BackoffMultiplier:    p.BackoffMultiplier,
+			RetryableStatusCodes: []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"},
+		}}
+		for _, service := range retriedServices {
+			mc.Name = append(mc.Name, name{Service: service})
+		}
+		cfg.MethodConfig = append(cfg.MethodConfig, mc)
+		// Hedged reads are retried by hedgingInterceptor, which counts
+		// hedges and retries against the same limit. Their config of
+		// their own overrides the one of their service.
+		if p.hedged() {
+			reads := methodConfig{}
+			for method := range readMethods {
+				parts := strings.Split(method, "/")
+				reads.Name = append(reads.Name, name{Service: parts[1], Method: parts[2]})
+			}
+			sort.Slice(reads.Name, func(i, j int) bool {
+				return reads.Name[i].Service+"/"+reads.Name[i].Method < reads.Name[j].Service+"/"+reads.Name[j].Method
+			})
+			cfg.MethodConfig = append(cfg.MethodConfig, reads)
+		}
+		// Stop retrying while most calls fail, rather than piling on.
+		cfg.RetryThrottling = &throttling{MaxTokens: 10, TokenRatio: 0.1}
+	}
+	data, err := json.Marshal(cfg)
+	return string(data), err
+}
+
+// seconds formats d as a protobuf Duration in JSON, e.g. "0.1s".
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%gs", d.Seconds())
+}
+
+// WithIdempotencyKey returns a copy of ctx making the call it is used
+// for carry key, instead of a key of its own. Reuse a key to make a call
+// again after the client gave up on it, e.g. from another process.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, IdempotencyKeyHeader, key)
+}
+
+// NewIdempotencyKey returns a random key.
+func NewIdempotencyKey() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("reading random bytes: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// idempotencyInterceptor adds a key to calls that change state and have
+// none. Retries resend the metadata of the call, so all attempts share
+// the key.
+func idempotencyInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if writeMethods[method] {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			if len(md.Get(IdempotencyKeyHeader)) == 0 {
+				ctx = WithIdempotencyKey(ctx, NewIdempotencyKey())
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// hedgingInterceptor sends reads again when they take longer than
+// p.HedgingDelay, up to p.MaxHedges times, and returns the first response.
+// The service config leaves hedged reads alone, so failed attempts are
+// retried here with p's backoff: a call makes p.MaxAttempts attempts at
+// most, hedges included, or one more than p.MaxHedges if that is more.
+func hedgingInterceptor(p RetryPolicy) grpc.UnaryClientInterceptor {
+	limit := p.MaxAttempts
+	if limit < p.MaxHedges+1 {
+		limit = p.MaxHedges + 1
+	}
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		out, ok := reply.(proto.Message)
+		if !readMethods[method] || !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		// Losing attempts are cancelled once a response is in.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		type result struct {
+			attempt *attempt
+			reply   proto.Message
+			err     error
+		}
+		results := make(chan result, limit)
+		sent, pending, hedges, retries := 0, 0, 0, 0
+		send := func() {
+			a := newAttempt(opts)
+			r := out.ProtoReflect().New().Interface()
+			go func() {
+				results <- result{a, r, invoker(ctx, method, req, r, cc, a.opts...)}
+			}()
+			sent++
+			pending++
+		}
+		send()
+		hedge := time.NewTimer(p.HedgingDelay)
+		defer hedge.Stop()
+		// retry is set while waiting to retry after every attempt failed.
+		// No hedges go out meanwhile, the retry takes their place.
+		var retry <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case <-hedge.C:
+				if hedges < p.MaxHedges && sent < limit {
+					send()
+					hedges++
+					hedge.Reset(p.HedgingDelay)
+				}
+			case <-retry:
+				retry = nil
+				if sent < limit {
+					send()
+					hedge.Reset(p.HedgingDelay)
+				}
+			case r := <-results:
+				pending--
+				if r.err == nil {
+					r.attempt.report(opts)
+					proto.Reset(out)
+					proto.Merge(out, r.reply)
+					return nil
+				}
+				// Errors other than the retried ones would be the same
+				// for every attempt.
+				code := status.Code(r.err)
+				retryable := code == codes.Unavailable || code == codes.ResourceExhausted
+				if !retryable || (pending == 0 && sent >= limit) {
+					r.attempt.report(opts)
+					return r.err
+				}
+				if pending == 0 {
+					if !hedge.Stop() {
+						select {
+						case <-hedge.C:
+						default:
+						}
+					}
+					retry = time.After(r.attempt.backoff(p, retries))
+					retries++
+				}
+			}
+		}
+	}
+}
+
+// attempt is one of the calls a hedged read makes. Attempts run at the
+// same time, so each gets header, trailer and peer targets of its own in
+// place of the caller's, which only the attempt whose outcome is returned
+// fills in. The trailer is always kept, for the pushback of the server.
+type attempt struct {
+	opts    []grpc.CallOption
+	header  metadata.MD
+	trailer metadata.MD
+	peer    peer.Peer
+}
+
+func newAttempt(opts []grpc.CallOption) *attempt {
+	a := &attempt{opts: make([]grpc.CallOption, 0, len(opts)+1)}
+	for _, opt := range opts {
+		switch opt.(type) {
+		case grpc.HeaderCallOption:
+			opt = grpc.Header(&a.header)
+		case grpc.TrailerCallOption:
+			continue
+		case grpc.PeerCallOption:
+			opt = grpc.Peer(&a.peer)
+		}
+		a.opts = append(a.opts, opt)
+	}
+	a.opts = append(a.opts, grpc.Trailer(&a.trailer))
+	return a
+}
+
+// report fills in the caller's targets among opts.
+func (a *attempt) report(opts []grpc.CallOption) {
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case grpc.HeaderCallOption:
+			*opt.HeaderAddr = a.header
+		case grpc.TrailerCallOption:
+			*opt.TrailerAddr = a.trailer
+		case grpc.PeerCallOption:
+			*opt.PeerAddr = a.peer
+		}
+	}
+}
+
+// backoff is how long to wait before the retry following a's failure:
+// the pushback the server sent, or else a random part of p's backoff for
+// the given number of earlier retries, as gRPC waits.
+func (a *attempt) backoff(p RetryPolicy, retries int) time.Duration {
+	if v := a.trailer.Get(pushbackHeader); len(v) == 1 {
+		if ms, err := strconv.Atoi(v[0]); err == nil && ms >= 0 {
+			return time.Duration(ms) * time.Millisecond
+		}
+	}
+	limit := float64(p.InitialBackoff) * math.Pow(p.BackoffMultiplier, float64(retries))
+	if limit > float64(p.MaxBackoff) {
+		limit = float64(p.MaxBackoff)
+	}
+	return time.Duration(mathrand.Float64() * limit)
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestServiceConfig(t *testing.T) {
+	type methodConfig struct {
+		Name []struct {
+			Service string `json:"service"`
+			Method  string `json:"method"`
+		} `json:"name"`
+		RetryPolicy *struct {
+			MaxAttempts int `json:"maxAttempts"`
+		} `json:"retryPolicy"`
+	}
+	parse := func(p RetryPolicy) []methodConfig {
+		data, err := p.ServiceConfig()
+		if err != nil {
+			t.Fatalf("ServiceConfig: %v", err)
+		}
+		var cfg struct {
+			MethodConfig []methodConfig `json:"methodConfig"`
+		}
+		if err := json.Unmarshal([]byte(data), &cfg); err != nil {
+			t.Fatalf("parsing %s: %v", data, err)
+		}
+		return cfg.MethodConfig
+	}
+
+	cfg := parse(RetryPolicy{})
+	if len(cfg) != 2 || cfg[0].RetryPolicy == nil || cfg[0].RetryPolicy.MaxAttempts != 5 {
+		t.Fatalf("got %+v, want a retry policy for the services and one for the reads", cfg)
+	}
+	if cfg[1].RetryPolicy != nil || len(cfg[1].Name) != len(readMethods) {
+		t.Errorf("hedged reads got %+v, want every read without a retry policy", cfg[1])
+	}
+	for _, n := range cfg[1].Name {
+		if !readMethods["/"+n.Service+"/"+n.Method] {
+			t.Errorf("%s/%s is not a read", n.Service, n.Method)
+		}
+	}
+
+	if cfg := parse(RetryPolicy{HedgingDelay: -1}); len(cfg) != 1 {
+		t.Errorf("without hedging got %+v, want only the retry policy", cfg)
+	}
+	if cfg := parse(RetryPolicy{MaxAttempts: 1}); len(cfg) != 0 {
+		t.Errorf("without retries got %+v, want no method config", cfg)
+	}
+}
+
+// fakeInvoker answers the calls of a hedged read, filling in the header
+// and trailer the call options ask for the way gRPC does.
+type fakeInvoker struct {
+	calls int32
+	// answer returns the outcome of the nth call, counting from 1, and
+	// may block until ctx ends.
+	answer func(ctx context.Context, n int32) (string, error)
+	// pushback is the grpc-retry-pushback-ms trailer of every call, "1"
+	// when empty.
+	pushback string
+}
+
+func (f *fakeInvoker) invoke(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	n := atomic.AddInt32(&f.calls, 1)
+	value, err := f.answer(ctx, n)
+	md := metadata.Pairs("attempt", strconv.Itoa(int(n)))
+	pushback := f.pushback
+	if pushback == "" {
+		pushback = "1"
+	}
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case grpc.HeaderCallOption:
+			*opt.HeaderAddr = md
+		case grpc.TrailerCallOption:
+			*opt.TrailerAddr = metadata.Join(md, metadata.Pairs(pushbackHeader, pushback))
+		}
+	}
+	if err == nil {
+		reply.(*wrapperspb.StringValue).Value = value
+	}
+	return err
+}
+
+// call makes a hedged read with p, asking for its header and trailer.
+func (f *fakeInvoker) call(t *testing.T, p RetryPolicy) (string, metadata.MD, metadata.MD, error) {
+	t.Helper()
+	var header, trailer metadata.MD
+	value, err := f.callWith(p, grpc.Header(&header), grpc.Trailer(&trailer))
+	return value, header, trailer, err
+}
+
+func (f *fakeInvoker) callWith(p RetryPolicy, opts ...grpc.CallOption) (string, error) {
+	reply := &wrapperspb.StringValue{}
+	err := hedgingInterceptor(p.withDefaults())(context.Background(), "/greet.GreetService/Greet", &wrapperspb.StringValue{}, reply, nil, f.invoke, opts...)
+	return reply.GetValue(), err
+}
+
+func TestHedgingInterceptor(t *testing.T) {
+	// The first call is slow, so the hedge sent after it wins. The first
+	// call then fills in its own header, not the caller's, as it ends.
+	f := &fakeInvoker{answer: func(ctx context.Context, n int32) (string, error) {
+		if n == 1 {
+			<-ctx.Done()
+			return "", status.FromContextError(ctx.Err()).Err()
+		}
+		return "hedge", nil
+	}}
+	value, header, trailer, err := f.call(t, RetryPolicy{HedgingDelay: time.Millisecond})
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if value != "hedge" || header.Get("attempt")[0] != "2" || trailer.Get("attempt")[0] != "2" {
+		t.Errorf("got %q with header %v and trailer %v, want those of the hedge", value, header, trailer)
+	}
+	if calls := atomic.LoadInt32(&f.calls); calls != 2 {
+		t.Errorf("made %d calls, want 2", calls)
+	}
+}
+
+func TestHedgingInterceptorRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		code      codes.Code
+		wantCalls int32
+	}{
+		{"attempts include hedges", RetryPolicy{MaxAttempts: 3, HedgingDelay: time.Millisecond, MaxHedges: 2}, codes.Unavailable, 3},
+		{"retried after failing fast", RetryPolicy{MaxAttempts: 4, HedgingDelay: time.Hour, MaxHedges: 1}, codes.ResourceExhausted, 4},
+		{"hedges raise the limit", RetryPolicy{MaxAttempts: 1, HedgingDelay: time.Millisecond, MaxHedges: 2}, codes.Unavailable, 3},
+		{"not retried", RetryPolicy{MaxAttempts: 5, HedgingDelay: time.Hour, MaxHedges: 1}, codes.InvalidArgument, 1},
+	}
+	for _, tt := range tests {
+		f := &fakeInvoker{answer: func(ctx context.Context, n int32) (string, error) {
+			return "", status.Error(tt.code, "failed")
+		}}
+		_, _, trailer, err := f.call(t, tt.policy)
+		if status.Code(err) != tt.code {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.code)
+		}
+		if calls := atomic.LoadInt32(&f.calls); calls != tt.wantCalls {
+			t.Errorf("%s: made %d calls, want %d", tt.name, calls, tt.wantCalls)
+		}
+		if len(trailer.Get("attempt")) != 1 {
+			t.Errorf("%s: got trailer %v, want the one of the failed attempt", tt.name, trailer)
+		}
+	}
+}
+
+func TestHedgingInterceptorPushback(t *testing.T) {
+	// The typed clients ask for no trailer, yet the server's pushback
+	// still spaces the retries rather than the tiny backoff.
+	f := &fakeInvoker{pushback: "100", answer: func(ctx context.Context, n int32) (string, error) {
+		if n == 1 {
+			return "", status.Error(codes.ResourceExhausted, "slow down")
+		}
+		return "retried", nil
+	}}
+	start := time.Now()
+	value, err := f.callWith(RetryPolicy{InitialBackoff: time.Microsecond, MaxBackoff: time.Microsecond, HedgingDelay: time.Hour})
+	if err != nil || value != "retried" {
+		t.Fatalf("got %q, %v, want the retry's response", value, err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("retried after %v, want the 100ms pushback", elapsed)
+	}
+}
+
+func TestHedgingInterceptorNoHedgeWhileRetrying(t *testing.T) {
+	// The first call fails at once and the retry waits for the pushback,
+	// during which the hedge delay passes. Only the retry may go out, or
+	// the call would take three attempts of the two allowed.
+	f := &fakeInvoker{pushback: "50", answer: func(ctx context.Context, n int32) (string, error) {
+		if n > 1 {
+			time.Sleep(20 * time.Millisecond)
+		}
+		return "", status.Error(codes.Unavailable, "down")
+	}}
+	for i := 0; i < 5; i++ {
+		atomic.StoreInt32(&f.calls, 0)
+		_, err := f.callWith(RetryPolicy{MaxAttempts: 2, HedgingDelay: 10 * time.Millisecond, MaxHedges: 1})
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("got %v, want Unavailable", err)
+		}
+		if calls := atomic.LoadInt32(&f.calls); calls != 2 {
+			t.Fatalf("made %d calls, want 2", calls)
+		}
+	}
+}
@@ -0,0 +1,474 @@
+// Package config loads the settings of the gRPC servers. Each setting is
+// taken from, in increasing order of precedence: the server's defaults, a
+// YAML or TOML file, environment variables and command-line flags.
+//
+// Every flag has a matching environment variable named after the server
+// and the flag, e.g. -tls-cert of the greet server is GREET_TLS_CERT. The
+// file is given with -config or <NAME>_CONFIG. A .env file in the working
+// directory is read into the environment when present.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the settings shared by all servers.
+type Config struct {
+	// Listen is the host:port the gRPC server binds.
+	Listen   string   `yaml:"listen" toml:"listen"`
+	Services Services `yaml:"services" toml:"services"`
+	// ShutdownTimeout is how long in-flight calls may take to finish once
+	// the server is asked to stop, before they are cut off.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+	TLS             TLS           `yaml:"tls" toml:"tls"`
+	Metrics         Metrics       `yaml:"metrics" toml:"metrics"`
+	Tracing         Tracing       `yaml:"tracing" toml:"tracing"`
+	Logging         Logging       `yaml:"logging" toml:"logging"`
+	RateLimit       RateLimit     `yaml:"rate_limit" toml:"rate_limit"`
+	Blog            Blog          `yaml:"blog" toml:"blog"`
+}
+
+// Metrics configures the Prometheus endpoint.
+type Metrics struct {
+	// Listen is the host:port serving /metrics over HTTP; metrics are not
+	// served when it is empty.
+	Listen string `yaml:"listen" toml:"listen"`
+}
+
+// Services picks the services the server registers.
+type Services struct {
+	Greet      bool `yaml:"greet" toml:"greet"`
+	Calculator bool `yaml:"calculator" toml:"calculator"`
+	Blog       bool `yaml:"blog" toml:"blog"`
+}
+
+// TLS configures the server certificate and, optionally, mutual TLS.
+// The files are read again when they change on disk.
+type TLS struct {
+	Enabled  bool   `yaml:"enabled" toml:"enabled"`
+	CertFile string `yaml:"cert_file" toml:"cert_file"`
+	KeyFile  string `yaml:"key_file" toml:"key_file"`
+	// ClientCAFile, when set, makes clients present a certificate signed
+	// by one of the CAs in this file.
+	ClientCAFile string `yaml:"client_ca_file" toml:"client_ca_file"`
+}
+
+// Tracing configures where OpenTelemetry spans are exported.
+type Tracing struct {
+	// Exporter is "none", "otlp" (a collector over gRPC), "stdout" or
+	// "file" (JSON lines appended to File).
+	Exporter string `yaml:"exporter" toml:"exporter"`
+	// Endpoint is the host:port of the OTLP collector.
+	Endpoint string `yaml:"endpoint" toml:"endpoint"`
+	// Insecure talks to the collector without TLS.
+	Insecure bool   `yaml:"insecure" toml:"insecure"`
+	File     string `yaml:"file" toml:"file"`
+	// SampleRatio is the share of new traces that are recorded; calls
+	// that are part of a sampled trace always are.
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio"`
+}
+
+func (t *Tracing) flags(fs *flag.FlagSet) {
+	fs.StringVar(&t.Exporter, "tracing-exporter", t.Exporter, "span exporter: none, otlp, stdout or file")
+	fs.StringVar(&t.Endpoint, "tracing-endpoint", t.Endpoint, "OTLP collector host:port")
+	fs.BoolVar(&t.Insecure, "tracing-insecure", t.Insecure, "connect to the OTLP collector without TLS")
+	fs.StringVar(&t.File, "tracing-file", t.File, "file the file exporter appends spans to")
+	fs.Float64Var(&t.SampleRatio, "tracing-sample-ratio", t.SampleRatio, "share of new traces to record, 0 to 1")
+}
+
+// Validate reports the first tracing setting that cannot work.
+func (t *Tracing) Validate() error {
+	switch t.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if t.Endpoint == "" {
+			return errors.New("otlp tracing needs an endpoint")
+		}
+	case "file":
+		if t.File == "" {
+			return errors.New("file tracing needs a file")
+		}
+	default:
+		return fmt.Errorf("unknown tracing exporter %q, want none, otlp, stdout or file", t.Exporter)
+	}
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		return errors.New("tracing sample ratio must be between 0 and 1")
+	}
+	return nil
+}
+
+// Logging configures the JSON request log.
+type Logging struct {
+	// Level is the least severe level logged: debug, info, warn or error.
+	Level string `yaml:"level" toml:"level"`
+	// Payloads also logs request and response messages, at debug level.
+	Payloads bool `yaml:"payloads" toml:"payloads"`
+	// Redact lists the message fields, by proto name, whose values are
+	// left out of logged payloads.
+	Redact []string `yaml:"redact" toml:"redact"`
+}
+
+func (l *Logging) flags(fs *flag.FlagSet) {
+	fs.StringVar(&l.Level, "log-level", l.Level, "least severe level logged: debug, info, warn or error")
+	fs.BoolVar(&l.Payloads, "log-payloads", l.Payloads, "log request and response messages at debug level")
+	fs.Var((*listValue)(&l.Redact), "log-redact", "comma-separated message fields left out of logged payloads")
+}
+
+// Validate reports the first logging setting that cannot work.
+func (l *Logging) Validate() error {
+	switch l.Level {
+	case "debug", "info", "warn", "error":
+		return nil
+	}
+	return fmt.Errorf("unknown log level %q, want debug, info, warn or error", l.Level)
+}
+
+// RateLimit caps how much of the server each caller may use. Callers are
+// told apart by their bearer token's subject, else their client
+// certificate's subject, else their IP address.
+type RateLimit struct {
+	// Default limits every method that Methods leaves out.
+	Default Limit `yaml:"default" toml:"default"`
+	// Methods limits single methods, keyed by full name such as
+	// "/calculator.CalculatorService/ComputeAverage", in place of Default.
+	Methods map[string]Limit `yaml:"methods" toml:"methods"`
+	// MaxStreams caps the streaming calls one caller may have open at
+	// once, over all methods; zero means no cap.
+	MaxStreams int `yaml:"max_streams" toml:"max_streams"`
+}
+
+// Limit is the quota of one caller on one method. Zero fields do not
+// limit anything.
+type Limit struct {
+	// Rate is the number of calls per second the caller may make, on
+	// average; Burst is how many it may make at once. Burst defaults to
+	// Rate, rounded up.
+	Rate  float64 `yaml:"rate" toml:"rate"`
+	Burst int     `yaml:"burst" toml:"burst"`
+	// MaxStreams caps the calls of the method the caller may have open at
+	// once, for streaming methods.
+	MaxStreams int `yaml:"max_streams" toml:"max_streams"`
+	// MessageRate is the number of messages per second the caller may
+	// stream to the server on one call; faster senders are slowed down.
+	MessageRate float64 `yaml:"message_rate" toml:"message_rate"`
+}
+
+func (r *RateLimit) flags(fs *flag.FlagSet) {
+	fs.Float64Var(&r.Default.Rate, "rate-limit", r.Default.Rate, "calls per second each caller may make to each method; 0 means no limit")
+	fs.IntVar(&r.Default.Burst, "rate-burst", r.Default.Burst, "calls each caller may make to each method at once; defaults to -rate-limit")
+	fs.IntVar(&r.MaxStreams, "max-streams", r.MaxStreams, "streaming calls each caller may have open at once; 0 means no cap")
+	fs.Float64Var(&r.Default.MessageRate, "stream-message-rate", r.Default.MessageRate, "messages per second a caller may send on one stream; 0 means no limit")
+}
+
+// Validate reports the first rate limit setting that cannot work.
+func (r *RateLimit) Validate() error {
+	if r.MaxStreams < 0 {
+		return errors.New("rate limit max_streams must not be negative")
+	}
+	if err := r.Default.validate(); err != nil {
+		return fmt.Errorf("default rate limit: %v", err)
+	}
+	for method, limit := range r.Methods {
+		if !strings.HasPrefix(method, "/") || strings.Count(method, "/") != 2 {
+			return fmt.Errorf("rate limit method %q must be a full name such as /pkg.Service/Method", method)
+		}
+		if err := limit.validate(); err != nil {
+			return fmt.Errorf("rate limit of %s: %v", method, err)
+		}
+	}
+	return nil
+}
+
+func (l Limit) validate() error {
+	if l.Rate < 0 || l.Burst < 0 || l.MaxStreams < 0 || l.MessageRate < 0 {
+		return errors.New("limits must not be negative")
+	}
+	return nil
+}
+
+// listValue is a flag.Value of comma-separated strings.
+type listValue []string
+
+func (v *listValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return strings.Join(*v, ",")
+}
+
+func (v *listValue) Set(s string) error {
+	*v = nil
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*v = append(*v, item)
+		}
+	}
+	return nil
+}
+
+// Blog configures the blog service.
+type Blog struct {
+	// Store is the storage backend, "mongo" or "memory".
+	Store string `yaml:"store" toml:"store"`
+	// AuthKeyFile holds the HMAC key for bearer tokens; auth is off when
+	// it is empty.
+	AuthKeyFile string `yaml:"auth_key_file" toml:"auth_key_file"`
+	// IdempotencyTTL is how long the response of a call sent with an
+	// idempotency key is kept to answer repeats of the call; keys are
+	// ignored when it is zero.
+	IdempotencyTTL time.Duration `yaml:"idempotency_ttl" toml:"idempotency_ttl"`
+	Mongo          Mongo         `yaml:"mongo" toml:"mongo"`
+}
+
+// Mongo locates the blog collection.
+type Mongo struct {
+	URI        string `yaml:"uri" toml:"uri"`
+	Database   string `yaml:"database" toml:"database"`
+	Collection string `yaml:"collection" toml:"collection"`
+	// PingInterval is how often the database is pinged to report the
+	// blog service's health.
+	PingInterval time.Duration `yaml:"ping_interval" toml:"ping_interval"`
+}
+
+// Default returns the settings servers start from before overriding the
+// ones that differ for them. All services are on.
+func Default() Config {
+	return Config{
+		Listen:          "0.0.0.0:50051",
+		ShutdownTimeout: 10 * time.Second,
+		Services: Services{
+			Greet:      true,
+			Calculator: true,
+			Blog:       true,
+		},
+		TLS: TLS{
+			CertFile: "ssl/server.crt",
+			KeyFile:  "ssl/server.pem",
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			File:        "traces.json",
+			SampleRatio: 1,
+		},
+		Logging: Logging{
+			Level:  "info",
+			Redact: []string{"content"},
+		},
+		Blog: Blog{
+			Store:          "mongo",
+			IdempotencyTTL: 24 * time.Hour,
+			Mongo: Mongo{
+				Database:     "mydb",
+				Collection:   "blog",
+				PingInterval: 10 * time.Second,
+			},
+		},
+	}
+}
+
+// flags binds every setting of c to a flag of fs.
+func (c *Config) flags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Listen, "listen", c.Listen, "host:port to listen on")
+	fs.BoolVar(&c.Services.Greet, "greet", c.Services.Greet, "serve the GreetService")
+	fs.BoolVar(&c.Services.Calculator, "calculator", c.Services.Calculator, "serve the CalculatorService")
+	fs.BoolVar(&c.Services.Blog, "blog", c.Services.Blog, "serve the BlogService")
+	fs.DurationVar(&c.ShutdownTimeout, "shutdown-timeout", c.ShutdownTimeout, "how long to let in-flight calls finish when stopping")
+	fs.BoolVar(&c.TLS.Enabled, "tls", c.TLS.Enabled, "serve over TLS")
+	fs.StringVar(&c.TLS.CertFile, "tls-cert", c.TLS.CertFile, "TLS certificate file")
+	fs.StringVar(&c.TLS.KeyFile, "tls-key", c.TLS.KeyFile, "TLS private key file")
+	fs.StringVar(&c.TLS.ClientCAFile, "tls-client-ca", c.TLS.ClientCAFile, "CA file verifying client certificates; mutual TLS is off when empty")
+	fs.StringVar(&c.Metrics.Listen, "metrics-listen", c.Metrics.Listen, "host:port serving Prometheus /metrics; off when empty")
+	c.Tracing.flags(fs)
+	c.Logging.flags(fs)
+	c.RateLimit.flags(fs)
+	fs.StringVar(&c.Blog.Store, "store", c.Blog.Store, "blog storage backend: mongo or memory")
+	fs.StringVar(&c.Blog.AuthKeyFile, "auth-key", c.Blog.AuthKeyFile, "HMAC key file for bearer tokens; auth is off when empty")
+	fs.DurationVar(&c.Blog.IdempotencyTTL, "idempotency-ttl", c.Blog.IdempotencyTTL, "how long to replay responses to calls repeating an idempotency key; 0 turns keys off")
+	fs.StringVar(&c.Blog.Mongo.URI, "mongo-uri", c.Blog.Mongo.URI, "MongoDB connection string (also read from DB_CONNECTION)")
+	fs.StringVar(&c.Blog.Mongo.Database, "mongo-database", c.Blog.Mongo.Database, "MongoDB database of the blogs")
+	fs.StringVar(&c.Blog.Mongo.Collection, "mongo-collection", c.Blog.Mongo.Collection, "MongoDB collection of the blogs")
+	fs.DurationVar(&c.Blog.Mongo.PingInterval, "mongo-ping-interval", c.Blog.Mongo.PingInterval, "how often to ping MongoDB for health checks")
+}
+
+// Load builds the configuration of the server called name from defaults,
+// the config file, the environment and args, which exclude the program
+// name. The result is validated.
+func Load(name string, defaults Config, args []string) (*Config, error) {
+	c := defaults
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := fs.String("config", "", "YAML or TOML config file")
+	c.flags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	// Flags win over everything else, so remember them and start over.
+	set := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = f.Value.String()
+	})
+	c = defaults
+
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("loading .env: %v", err)
+	}
+	prefix := strings.ToUpper(name) + "_"
+	if *configFile == "" {
+		*configFile = os.Getenv(prefix + "CONFIG")
+	}
+	if *configFile != "" {
+		if err := c.loadFile(*configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	// DB_CONNECTION predates the prefixed variables and still works.
+	if uri, ok := os.LookupEnv("DB_CONNECTION"); ok {
+		c.Blog.Mongo.URI = uri
+	}
+	if err := applyEnv(fs, prefix); err != nil {
+		return nil, err
+	}
+	for name, value := range set {
+		if err := fs.Set(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// applyEnv sets every flag of fs, except -config, that has a variable in
+// the environment, named prefix followed by the flag's name in upper case
+// with dashes turned into underscores.
+func applyEnv(fs *flag.FlagSet, prefix string) error {
+	var envErr error
+	fs.VisitAll(func(f *flag.Flag) {
+		key := prefix + strings.ToUpper(strings.Replace(f.Name, "-", "_", -1))
+		if value, ok := os.LookupEnv(key); ok && f.Name != "config" && envErr == nil {
+			if err := f.Value.Set(value); err != nil {
+				envErr = fmt.Errorf("invalid %s: %v", key, err)
+			}
+		}
+	})
+	return envErr
+}
+
+// TracingFromEnv returns the tracing settings of a client called name:
+// the defaults, overridden by environment variables such as
+// <NAME>_TRACING_EXPORTER.
+func TracingFromEnv(name string) (Tracing, error) {
+	t := Default().Tracing
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	t.flags(fs)
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Tracing{}, fmt.Errorf("loading .env: %v", err)
+	}
+	if err := applyEnv(fs, strings.ToUpper(name)+"_"); err != nil {
+		return Tracing{}, err
+	}
+	return t, t.Validate()
+}
+
+// loadFile reads settings from a YAML or TOML file, chosen by extension.
+// Settings the file leaves out keep their current values.
+func (c *Config) loadFile(path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+		if err := dec.Decode(c); err != nil && err != io.EOF {
+			return fmt.Errorf("parsing %s: %v", path, err)
+		}
+	case ".toml":
+		md, err := toml.DecodeFile(path, c)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %v", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("parsing %s: unknown setting %q", path, undecoded[0].String())
+		}
+	default:
+		return fmt.Errorf("config file %s must end in .yaml, .yml or .toml", path)
+	}
+	return nil
+}
+
+// Validate reports the first setting that cannot work.
+func (c *Config) Validate() error {
+	if _, port, err := net.SplitHostPort(c.Listen); err != nil || port == "" {
+		return fmt.Errorf("listen address %q must be host:port", c.Listen)
+	}
+	if c.Metrics.Listen != "" {
+		if _, port, err := net.SplitHostPort(c.Metrics.Listen); err != nil || port == "" {
+			return fmt.Errorf("metrics listen address %q must be host:port", c.Metrics.Listen)
+		}
+	}
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
+	if err := c.Logging.Validate(); err != nil {
+		return err
+	}
+	if err := c.RateLimit.Validate(); err != nil {
+		return err
+	}
+	if c.ShutdownTimeout < 0 {
+		return errors.New("shutdown timeout must not be negative")
+	}
+	if c.TLS.Enabled && (c.TLS.CertFile == "" || c.TLS.KeyFile == "") {
+		return errors.New("tls needs both a certificate and a key file")
+	}
+	if c.TLS.ClientCAFile != "" && !c.TLS.Enabled {
+		return errors.New("tls client_ca_file needs tls to be enabled")
+	}
+	if !c.Services.Greet && !c.Services.Calculator && !c.Services.Blog {
+		return errors.New("no service is enabled")
+	}
+	if !c.Services.Blog {
+		return nil
+	}
+	if c.Blog.IdempotencyTTL < 0 {
+		return errors.New("idempotency ttl must not be negative")
+	}
+	switch c.Blog.Store {
+	case "memory":
+	case "mongo":
+		if c.Blog.Mongo.URI == "" {
+			return errors.New("mongo store needs a connection string, set DB_CONNECTION or -mongo-uri")
+		}
+		if c.Blog.Mongo.Database == "" || c.Blog.Mongo.Collection == "" {
+			return errors.New("mongo store needs a database and a collection")
+		}
+		if c.Blog.Mongo.PingInterval <= 0 {
+			return errors.New("mongo ping interval must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown blog store %q, want mongo or memory", c.Blog.Store)
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv unsets the variables Load reads for the server called test,
+// restoring them when t ends.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, kv := range os.Environ() {
+		key := kv[:strings.Index(kv, "=")]
+		if strings.HasPrefix(key, "TEST_") || key == "DB_CONNECTION" {
+			t.Setenv(key, "")
+			os.Unsetenv(key)
+		}
+	}
+}
+
+// writeFile writes a config file called name into a temporary directory
+// and returns its path.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// memoryDefaults are defaults that validate without a database.
+func memoryDefaults() Config {
+	c := Default()
+	c.Blog.Store = "memory"
+	return c
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := writeFile(t, "c.yaml", "listen: file:1\nshutdown_timeout: 3s\n")
+	tests := []struct {
+		name string
+		file bool
+		env  map[string]string
+		args []string
+		want string
+	}{
+		{"defaults", false, nil, nil, "0.0.0.0:50051"},
+		{"file", true, nil, nil, "file:1"},
+		{"env over file", true, map[string]string{"TEST_LISTEN": "env:2"}, nil, "env:2"},
+		{"flag over env", true, map[string]string{"TEST_LISTEN": "env:2"}, []string{"-listen", "flag:3"}, "flag:3"},
+		{"flag set to the default", true, map[string]string{"TEST_LISTEN": "env:2"}, []string{"-listen", "0.0.0.0:50051"}, "0.0.0.0:50051"},
+		{"config file from env", false, map[string]string{"TEST_CONFIG": file}, nil, "file:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			args := tt.args
+			if tt.file {
+				args = append([]string{"-config", file}, args...)
+			}
+			c, err := Load("test", memoryDefaults(), args)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if c.Listen != tt.want {
+				t.Errorf("listen = %q, want %q", c.Listen, tt.want)
+			}
+			// Settings nothing overrides keep the file's value.
+			if tt.file && c.ShutdownTimeout != 3*time.Second {
+				t.Errorf("shutdown timeout = %v, want 3s", c.ShutdownTimeout)
+			}
+		})
+	}
+}
+
+func TestLoadMongoURI(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		want string
+	}{
+		{"DB_CONNECTION", map[string]string{"DB_CONNECTION": "mongodb://legacy"}, nil, "mongodb://legacy"},
+		{"prefixed variable first", map[string]string{"DB_CONNECTION": "mongodb://legacy", "TEST_MONGO_URI": "mongodb://env"}, nil, "mongodb://env"},
+		{"flag first", map[string]string{"DB_CONNECTION": "mongodb://legacy"}, []string{"-mongo-uri", "mongodb://flag"}, "mongodb://flag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			c, err := Load("test", Default(), tt.args)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if c.Blog.Mongo.URI != tt.want {
+				t.Errorf("mongo uri = %q, want %q", c.Blog.Mongo.URI, tt.want)
+			}
+		})
+	}
+
+	clearEnv(t)
+	if _, err := Load("test", Default(), nil); err == nil || !strings.Contains(err.Error(), "connection string") {
+		t.Errorf("mongo store without a URI: got %v, want an error asking for one", err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	files := map[string]string{
+		"c.yaml": `
+shutdown_timeout: 1m30s
+blog:
+  idempotency_ttl: 2h
+  mongo:
+    ping_interval: 500ms
+rate_limit:
+  methods:
+    /blog.BlogService/CreateBlog:
+      rate: 2
+`,
+		"c.toml": `
+shutdown_timeout = "1m30s"
+[blog]
+idempotency_ttl = "2h"
+[blog.mongo]
+ping_interval = "500ms"
+[rate_limit.methods."/blog.BlogService/CreateBlog"]
+rate = 2
+`,
+	}
+	for name, content := range files {
+		clearEnv(t)
+		c, err := Load("test", memoryDefaults(), []string{"-config", writeFile(t, name, content)})
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if c.ShutdownTimeout != 90*time.Second || c.Blog.IdempotencyTTL != 2*time.Hour || c.Blog.Mongo.PingInterval != 500*time.Millisecond {
+			t.Errorf("%s: got durations %v, %v and %v, want 1m30s, 2h and 500ms", name, c.ShutdownTimeout, c.Blog.IdempotencyTTL, c.Blog.Mongo.PingInterval)
+		}
+		if c.RateLimit.Methods["/blog.BlogService/CreateBlog"].Rate != 2 {
+			t.Errorf("%s: method limits = %v", name, c.RateLimit.Methods)
+		}
+		// Left out settings keep their defaults.
+		if c.Listen != "0.0.0.0:50051" || c.Blog.Mongo.Database != "mydb" {
+			t.Errorf("%s: listen %q and database %q, want the defaults", name, c.Listen, c.Blog.Mongo.Database)
+		}
+	}
+
+	bad := map[string]string{
+		"unknown.yaml":  "listne: x:1\n",
+		"nested.yaml":   "blog:\n  mongo:\n    url: x\n",
+		"unknown.toml":  "listne = \"x:1\"\n",
+		"duration.yaml": "shutdown_timeout: soon\n",
+		"duration.toml": "shutdown_timeout = \"soon\"\n",
+		"c.json":        "{}",
+	}
+	for name, content := range bad {
+		clearEnv(t)
+		if _, err := Load("test", memoryDefaults(), []string{"-config", writeFile(t, name, content)}); err == nil {
+			t.Errorf("%s was accepted", name)
+		}
+	}
+
+	clearEnv(t)
+	if _, err := Load("test", memoryDefaults(), []string{"-config", "example.yaml", "-store", "memory"}); err != nil {
+		t.Errorf("example.yaml: %v", err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	clearEnv(t)
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+	}{
+		{"unknown flag", nil, []string{"-nope"}},
+		{"extra argument", nil, []string{"extra"}},
+		{"bad env value", map[string]string{"TEST_SHUTDOWN_TIMEOUT": "soon"}, nil},
+		{"missing file", nil, []string{"-config", "missing.yaml"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if _, err := Load("test", memoryDefaults(), tt.args); err == nil {
+				t.Errorf("Load succeeded")
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *Config)
+		want   string
+	}{
+		{"listen without port", func(c *Config) { c.Listen = "localhost" }, "listen address"},
+		{"metrics listen", func(c *Config) { c.Metrics.Listen = "9090" }, "metrics listen address"},
+		{"negative shutdown timeout", func(c *Config) { c.ShutdownTimeout = -time.Second }, "shutdown timeout"},
+		{"tls without key", func(c *Config) { c.TLS.Enabled = true; c.TLS.KeyFile = "" }, "certificate and a key"},
+		{"client CA without tls", func(c *Config) { c.TLS.ClientCAFile = "ca.crt" }, "client_ca_file"},
+		{"no service", func(c *Config) { c.Services = Services{} }, "no service"},
+		{"unknown store", func(c *Config) { c.Blog.Store = "disk" }, "disk"},
+		{"negative idempotency ttl", func(c *Config) { c.Blog.IdempotencyTTL = -time.Second }, "idempotency ttl"},
+		{"mongo ping interval", func(c *Config) {
+			c.Blog.Store = "mongo"
+			c.Blog.Mongo.URI = "mongodb://x"
+			c.Blog.Mongo.PingInterval = 0
+		}, "ping interval"},
+		{"negative rate", func(c *Config) { c.RateLimit.Default.Rate = -1 }, "default rate limit"},
+		{"rate limit method", func(c *Config) { c.RateLimit.Methods = map[string]Limit{"CreateBlog": {}} }, "full name"},
+		{"log level", func(c *Config) { c.Logging.Level = "loud" }, "loud"},
+		{"tracing exporter", func(c *Config) { c.Tracing.Exporter = "jaeger" }, "jaeger"},
+	}
+	for _, tt := range tests {
+		c := memoryDefaults()
+		tt.change(&c)
+		if err := c.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want an error about %q", tt.name, err, tt.want)
+		}
+	}
+
+	// Blog settings do not matter without the blog service.
+	c := memoryDefaults()
+	c.Services.Blog = false
+	c.Blog.Store = "disk"
+	if err := c.Validate(); err != nil {
+		t.Errorf("blog settings of a server without blogs: %v", err)
+	}
+	if c := memoryDefaults(); c.Validate() != nil {
+		t.Errorf("defaults: %v", c.Validate())
+	}
+}
@@ -2,4 +2,5 @@
 protoc greet/greetpb/greet.proto --go_out=plugins=grpc:.
 protoc calculator/calculatorpb/calculator.proto --go_out=plugins=grpc:.
 
+protoc blog/blogpb/rules.proto --go_out=plugins=grpc:.
 protoc blog/blogpb/blog.proto --go_out=plugins=grpc:.
\ No newline at end of file
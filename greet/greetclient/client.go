@@ -0,0 +1,136 @@
+// Package greetclient is a typed client of the GreetService.
+package greetclient
+
+import (
+	"context"
+
+	"github.com/shivkumar123g/grpc_go_course/client"
+	"github.com/shivkumar123g/grpc_go_course/greet/greetpb"
+	"google.golang.org/grpc"
+)
+
+// Client calls the GreetService. Its errors are *client.Error values.
+type Client struct {
+	cc  *grpc.ClientConn
+	rpc greetpb.GreetServiceClient
+}
+
+// New returns a Client using cc, which the caller keeps ownership of.
+func New(cc grpc.ClientConnInterface) *Client {
+	return &Client{rpc: greetpb.NewGreetServiceClient(cc)}
+}
+
+// Dial connects to the server at target.
+func Dial(ctx context.Context, target string, opts client.Options) (*Client, error) {
+	cc, err := client.Dial(ctx, target, opts)
+	if err != nil {
+		return nil, err
+	}
+	c := New(cc)
+	c.cc = cc
+	return c, nil
+}
+
+// Close closes the connection Dial opened.
+func (c *Client) Close() error {
+	if c.cc == nil {
+		return nil
+	}
+	return c.cc.Close()
+}
+
+func greeting(firstName, lastName string) *greetpb.Greeting {
+	return &greetpb.Greeting{FirstName: firstName, LastName: lastName}
+}
+
+// Greet returns the greeting for a person.
+func (c *Client) Greet(ctx context.Context, firstName, lastName string) (string, error) {
+	res, err := c.rpc.Greet(ctx, &greetpb.GreetRequest{Greeting: greeting(firstName, lastName)})
+	if err != nil {
+		return "", client.Convert(err)
+	}
+	return res.GetResult(), nil
+}
+
+// GreetWithDeadline is Greet on a slow path, taking three seconds, for
+// trying out deadlines.
+func (c *Client) GreetWithDeadline(ctx context.Context, firstName, lastName string) (string, error) {
+	res, err := c.rpc.GreetWithDeadline(ctx, &greetpb.GreetWithDeadlineRequest{Greeting: greeting(firstName, lastName)})
+	if err != nil {
+		return "", client.Convert(err)
+	}
+	return res.GetResult(), nil
+}
+
+// GreetManyTimes iterates over the greetings the server sends a person,
+// one a second.
+func (c *Client) GreetManyTimes(ctx context.Context, firstName, lastName string) *client.Iterator[string] {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.rpc.GreetManyTimes(ctx, &greetpb.GreetManyTimesRequest{Greeting: greeting(firstName, lastName)})
+	if err != nil {
+		return client.Failed[string](err, cancel)
+	}
+	return client.NewIterator(func() (string, error) {
+		res, err := stream.Recv()
+		return res.GetResult(), err
+	}, cancel)
+}
+
+// Person is a name to greet.
+type Person struct {
+	FirstName, LastName string
+}
+
+// LongGreet greets everyone in people with one message.
+func (c *Client) LongGreet(ctx context.Context, people []Person) (string, error) {
+	stream, err := c.rpc.LongGreet(ctx)
+	if err != nil {
+		return "", client.Convert(err)
+	}
+	for _, p := range people {
+		if err := stream.Send(&greetpb.LongGreetRequest{Greeting: greeting(p.FirstName, p.LastName)}); err != nil {
+			// The server ended the call; its status comes with the response.
+			break
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", client.Convert(err)
+	}
+	return res.GetResult(), nil
+}
+
+// GreetEveryone opens a stream greeting each person sent on it.
+func (c *Client) GreetEveryone(ctx context.Context) (*EveryoneStream, error) {
+	stream, err := c.rpc.GreetEveryone(ctx)
+	if err != nil {
+		return nil, client.Convert(err)
+	}
+	return &EveryoneStream{stream: stream}, nil
+}
+
+// EveryoneStream is an open GreetEveryone call. Send and Recv may be used
+// from different goroutines.
+type EveryoneStream struct {
+	stream greetpb.GreetService_GreetEveryoneClient
+}
+
+// Send asks for a greeting of a person.
+func (s *EveryoneStream) Send(firstName, lastName string) error {
+	return client.Convert(s.stream.Send(&greetpb.GreetEveryoneRequest{Greeting: greeting(firstName, lastName)}))
+}
+
+// CloseSend tells the server that no more people follow.
+func (s *EveryoneStream) CloseSend() error {
+	return client.Convert(s.stream.CloseSend())
+}
+
+// Recv returns the next greeting, or io.EOF once the server has greeted
+// everyone after CloseSend.
+func (s *EveryoneStream) Recv() (string, error) {
+	res, err := s.stream.Recv()
+	if err != nil {
+		return "", client.Convert(err)
+	}
+	return res.GetResult(), nil
+}
@@ -0,0 +1,99 @@
+// Package greetsvc implements the GreetService.
+package greetsvc
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/greet/greetpb"
+	"github.com/shivkumar123g/grpc_go_course/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type server struct{}
+
+func (*server) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
+	logging.FromContext(ctx).Info("Greet function was invoked", "first_name", req.GetGreeting().GetFirstName())
+	fistName := req.GetGreeting().GetFirstName()
+
+	result := "Hello " + fistName
+	res := &greetpb.GreetResponse{
+		Result: result,
+	}
+	return res, nil
+}
+
+func (*server) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
+	firstName := req.GetGreeting().GetFirstName()
+	for i := 0; i < 10; i++ {
+		res := &greetpb.GreetManyTimesResponse{
+			Result: "Hello " + firstName + " number " + strconv.Itoa(i),
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+		time.Sleep(time.Second)
+	}
+	return nil
+}
+
+func (*server) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
+	result := "Hello "
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&greetpb.LongGreetResponse{
+				Result: result,
+			})
+		}
+		if err != nil {
+			return err
+		}
+		firstName := req.GetGreeting().GetFirstName()
+		result += firstName + "! "
+	}
+}
+
+func (*server) GreetEveryone(stream greetpb.GreetService_GreetEveryoneServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		sErr := stream.Send(&greetpb.GreetEveryoneResponse{
+			Result: "Hello " + req.GetGreeting().GetFirstName() + "!\n",
+		})
+		if sErr != nil {
+			return sErr
+		}
+	}
+}
+
+func (*server) GreetWithDeadline(ctx context.Context, req *greetpb.GreetWithDeadlineRequest) (*greetpb.GreetWithDeadlineResponse, error) {
+	for i := 0; i < 3; i++ {
+		if ctx.Err() == context.Canceled {
+			return nil, status.Error(codes.Canceled, "The Client cancelled the request")
+		}
+		time.Sleep(time.Second)
+	}
+	logging.FromContext(ctx).Info("Greet function was invoked", "first_name", req.GetGreeting().GetFirstName())
+	fistName := req.GetGreeting().GetFirstName()
+
+	result := "Hello " + fistName
+	res := &greetpb.GreetWithDeadlineResponse{
+		Result: result,
+	}
+	return res, nil
+}
+
+// Register adds the GreetService to s.
+func Register(s *grpc.Server) {
+	greetpb.RegisterGreetServiceServer(s, &server{})
+}
@@ -0,0 +1,462 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/blog/blogclient"
+	"github.com/shivkumar123g/grpc_go_course/blog/blogpb"
+	"github.com/shivkumar123g/grpc_go_course/client"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var blogCommands = map[string]command{
+	"create": {
+		usage: "",
+		help:  "Create a blog from flags or a JSON file (CreateBlog).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			in := blogFlags(fs)
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) > 0 {
+					return usagef("create takes no arguments")
+				}
+				blog, _, err := in.blog(e.stdin)
+				if err != nil {
+					return err
+				}
+				blog, err = blogclient.New(e.cc).Create(ctx, blog)
+				if err != nil {
+					return err
+				}
+				return e.out.print(blogRow(blog))
+			}
+		},
+	},
+	"read": {
+		usage: "ID",
+		help:  "Show a blog (ReadBlog).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) != 1 {
+					return usagef("want a blog ID")
+				}
+				blog, err := blogclient.New(e.cc).Read(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				return e.out.print(blogRow(blog))
+			}
+		},
+	},
+	"update": {
+		usage: "ID",
+		help:  "Change the fields of a blog given by flags, or all fields from a JSON file (UpdateBlog).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			in := blogFlags(fs)
+			version := fs.Int64("expected-version", 0, "fail unless the stored blog has this version")
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) != 1 {
+					return usagef("want a blog ID")
+				}
+				blog, fields, err := in.blog(e.stdin)
+				if err != nil {
+					return err
+				}
+				if in.file == "" && len(fields) == 0 {
+					return usagef("nothing to update; set -title, -content, -author or -f")
+				}
+				blog.Id = args[0]
+				blog, err = blogclient.New(e.cc).Update(ctx, blog, blogclient.UpdateOptions{Fields: fields, ExpectedVersion: *version})
+				if err != nil {
+					return err
+				}
+				return e.out.print(blogRow(blog))
+			}
+		},
+	},
+	"delete": {
+		usage: "ID",
+		help:  "Delete a blog, softly unless -purge is set (DeleteBlog).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			version := fs.Int64("expected-version", 0, "fail unless the stored blog has this version")
+			purge := fs.Bool("purge", false, "remove the blog for good")
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) != 1 {
+					return usagef("want a blog ID")
+				}
+				opts := blogclient.DeleteOptions{ExpectedVersion: *version, Purge: *purge}
+				if err := blogclient.New(e.cc).Delete(ctx, args[0], opts); err != nil {
+					return err
+				}
+				return e.out.print(row{
+					header: []string{"DELETED"},
+					cells:  []string{args[0]},
+					value:  map[string]string{"blog_id": args[0]},
+				})
+			}
+		},
+	},
+	"undelete": {
+		usage: "ID",
+		help:  "Restore a soft-deleted blog (UndeleteBlog).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			version := fs.Int64("expected-version", 0, "fail unless the stored blog has this version")
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) != 1 {
+					return usagef("want a blog ID")
+				}
+				blog, err := blogclient.New(e.cc).Undelete(ctx, args[0], *version)
+				if err != nil {
+					return err
+				}
+				return e.out.print(blogRow(blog))
+			}
+		},
+	},
+	"list": {
+		usage: "",
+		help:  "List blogs as the server streams them (ListBlog).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			lf := listFlags(fs)
+			limit := fs.Int("limit", 0, "list at most this many blogs; all when 0")
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) > 0 {
+					return usagef("list takes no arguments")
+				}
+				req, err := lf.request()
+				if err != nil {
+					return err
+				}
+				req.PageSize = int32(*limit)
+				it := blogclient.New(e.cc).List(ctx, req)
+				defer it.Close()
+				for it.Next() {
+					if err := e.out.print(blogRow(it.Value())); err != nil {
+						return err
+					}
+				}
+				return it.Err()
+			}
+		},
+	},
+	"page": {
+		usage: "",
+		help:  "List a page of blogs; the next page token goes to stderr (ListBlogsPage).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			lf := listFlags(fs)
+			size := fs.Int("page-size", 0, "blogs per page; the server's default when 0")
+			token := fs.String("page-token", "", "next page token of an earlier page")
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) > 0 {
+					return usagef("page takes no arguments")
+				}
+				req, err := lf.request()
+				if err != nil {
+					return err
+				}
+				req.PageSize, req.PageToken = int32(*size), *token
+				blogs, next, err := blogclient.New(e.cc).ListPage(ctx, req)
+				if err != nil {
+					return err
+				}
+				for _, blog := range blogs {
+					if err := e.out.print(blogRow(blog)); err != nil {
+						return err
+					}
+				}
+				if next != "" {
+					fmt.Fprintf(os.Stderr, "Next page: -page-token %s\n", next)
+				}
+				return nil
+			}
+		},
+	},
+	"search": {
+		usage: "QUERY...",
+		help:  "Search blog titles and content, best match first (SearchBlogs).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			limit := fs.Int("limit", 0, "return at most this many results; the server's default when 0")
+			author := fs.String("author", "", "only search blogs by this author")
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) == 0 {
+					return usagef("want a query")
+				}
+				results, err := blogclient.New(e.cc).Search(ctx, strings.Join(args, " "), int32(*limit), *author)
+				if err != nil {
+					return err
+				}
+				for _, r := range results {
+					snippet := ""
+					if len(r.GetSnippets()) > 0 {
+						snippet = r.GetSnippets()[0]
+					}
+					err := e.out.print(row{
+						header: []string{"SCORE", "ID", "TITLE", "SNIPPET"},
+						cells:  []string{strconv.FormatFloat(r.GetScore(), 'f', 3, 64), r.GetBlog().GetId(), r.GetBlog().GetTitle(), snippet},
+						value:  r,
+					})
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		},
+	},
+	"watch": {
+		usage: "",
+		help:  "Print blog changes as they happen, until interrupted (WatchBlogs).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			author := fs.String("author", "", "only watch blogs by this author")
+			resume := fs.String("resume-token", "", "replay the events after this one first")
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) > 0 {
+					return usagef("watch takes no arguments")
+				}
+				e.out.live = true
+				it := blogclient.New(e.cc).Watch(ctx, &blogpb.WatchBlogsRequest{AutherId: *author, ResumeToken: *resume})
+				defer it.Close()
+				for it.Next() {
+					ev := it.Value()
+					err := e.out.print(row{
+						header: []string{"TIME", "EVENT", "ID", "TITLE", "VERSION", "RESUME TOKEN"},
+						cells: []string{
+							formatTime(ev.GetTime()), ev.GetType().String(), ev.GetBlog().GetId(),
+							ev.GetBlog().GetTitle(), strconv.FormatInt(ev.GetBlog().GetVersion(), 10), ev.GetResumeToken(),
+						},
+						value: ev,
+					})
+					if err != nil {
+						return err
+					}
+				}
+				// Interrupting is how a watch ends.
+				if ctx.Err() != nil {
+					return nil
+				}
+				return it.Err()
+			}
+		},
+	},
+	"batch-create": {
+		usage: "< blogs.jsonl",
+		help:  "Create the blogs read as JSON, one per line, from stdin or -f (BatchCreateBlogs).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			file := fs.String("f", "-", "file of JSON blogs, one per line; - for stdin")
+			allOrNothing := fs.Bool("all-or-nothing", false, "create none of the blogs if any is invalid")
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) > 0 {
+					return usagef("batch-create takes no arguments")
+				}
+				blogs, err := readBlogs(*file, e.stdin)
+				if err != nil {
+					return err
+				}
+				results, err := blogclient.New(e.cc).BatchCreate(ctx, blogs, *allOrNothing)
+				if err != nil {
+					return err
+				}
+				return printResults(e.out, results)
+			}
+		},
+	},
+	"batch-get": {
+		usage: "ID...",
+		help:  "Show several blogs (BatchGetBlogs).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) == 0 {
+					return usagef("want blog IDs")
+				}
+				results, err := blogclient.New(e.cc).BatchGet(ctx, args)
+				if err != nil {
+					return err
+				}
+				return printResults(e.out, results)
+			}
+		},
+	},
+	"batch-delete": {
+		usage: "ID...",
+		help:  "Delete several blogs, softly unless -purge is set (BatchDeleteBlogs).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			purge := fs.Bool("purge", false, "remove the blogs for good")
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) == 0 {
+					return usagef("want blog IDs")
+				}
+				results, err := blogclient.New(e.cc).BatchDelete(ctx, args, *purge)
+				if err != nil {
+					return err
+				}
+				return printResults(e.out, results)
+			}
+		},
+	},
+}
+
+// blogInput is a blog given by flags, a JSON file, or both, the flags
+// taking precedence.
+type blogInput struct {
+	fs                     *flag.FlagSet
+	file                   string
+	author, title, content string
+}
+
+func blogFlags(fs *flag.FlagSet) *blogInput {
+	in := &blogInput{fs: fs}
+	fs.StringVar(&in.file, "f", "", "JSON file of the blog, e.g. as printed by -o json; - for stdin")
+	fs.StringVar(&in.author, "author", "", "author ID")
+	fs.StringVar(&in.title, "title", "", "title")
+	fs.StringVar(&in.content, "content", "", "content")
+	return in
+}
+
+// blog returns the blog and the fields the flags set.
+func (in *blogInput) blog(stdin io.Reader) (*blogpb.Blog, []string, error) {
+	blog := &blogpb.Blog{}
+	if in.file != "" {
+		data, err := readFile(in.file, stdin)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := protojson.Unmarshal(data, blog); err != nil {
+			return nil, nil, fmt.Errorf("parsing %s: %v", in.file, err)
+		}
+	}
+	var fields []string
+	in.fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "author":
+			blog.AutherId = in.author
+			fields = append(fields, "auther_id")
+		case "title":
+			blog.Title = in.title
+			fields = append(fields, "title")
+		case "content":
+			blog.Content = in.content
+			fields = append(fields, "content")
+		}
+	})
+	return blog, fields, nil
+}
+
+// listSettings are the filters and order of list and page.
+type listSettings struct {
+	author         string
+	orderBy        string
+	desc           bool
+	includeDeleted bool
+}
+
+func listFlags(fs *flag.FlagSet) *listSettings {
+	l := &listSettings{}
+	fs.StringVar(&l.author, "author", "", "only list blogs by this author")
+	fs.StringVar(&l.orderBy, "order-by", "create_time", "sort by create_time or title")
+	fs.BoolVar(&l.desc, "desc", false, "sort in descending order")
+	fs.BoolVar(&l.includeDeleted, "include-deleted", false, "also list soft-deleted blogs")
+	return l
+}
+
+func (l *listSettings) request() (*blogpb.ListBlogRequest, error) {
+	order, ok := blogpb.ListBlogRequest_OrderBy_value[strings.ToUpper(l.orderBy)]
+	if !ok {
+		return nil, usagef("cannot order by %q, want create_time or title", l.orderBy)
+	}
+	return &blogpb.ListBlogRequest{
+		AutherId:       l.author,
+		OrderBy:        blogpb.ListBlogRequest_OrderBy(order),
+		Descending:     l.desc,
+		IncludeDeleted: l.includeDeleted,
+	}, nil
+}
+
+func blogRow(blog *blogpb.Blog) row {
+	return row{
+		header: []string{"ID", "AUTHOR", "TITLE", "VERSION", "UPDATED", "DELETED"},
+		cells: []string{
+			blog.GetId(), blog.GetAutherId(), blog.GetTitle(), strconv.FormatInt(blog.GetVersion(), 10),
+			formatTime(blog.GetUpdatedAt()), formatTime(blog.GetDeletedAt()),
+		},
+		value: blog,
+	}
+}
+
+func printResults(out *printer, results []blogclient.Result) error {
+	for i, r := range results {
+		code, msg := "OK", ""
+		value := map[string]interface{}{"index": i, "code": code}
+		if r.Err != nil {
+			rpcErr := r.Err.(*client.Error)
+			code, msg = rpcErr.Code.String(), rpcErr.Message
+			value["code"], value["message"] = code, msg
+		}
+		if r.Blog != nil {
+			blog, err := plain(r.Blog)
+			if err != nil {
+				return err
+			}
+			value["blog"] = blog
+		}
+		err := out.print(row{
+			header: []string{"#", "CODE", "ID", "TITLE", "MESSAGE"},
+			cells:  []string{strconv.Itoa(i), code, r.Blog.GetId(), r.Blog.GetTitle(), msg},
+			value:  value,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Local().Format(time.RFC3339)
+}
+
+func readFile(name string, stdin io.Reader) ([]byte, error) {
+	if name == "-" {
+		return ioutil.ReadAll(stdin)
+	}
+	return ioutil.ReadFile(name)
+}
+
+// readBlogs parses one JSON blog per non-blank line.
+func readBlogs(name string, stdin io.Reader) ([]*blogpb.Blog, error) {
+	var r io.Reader = stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	var blogs []*blogpb.Blog
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" {
+			continue
+		}
+		blog := &blogpb.Blog{}
+		if err := protojson.Unmarshal([]byte(text), blog); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		blogs = append(blogs, blog)
+	}
+	return blogs, sc.Err()
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/shivkumar123g/grpc_go_course/calculator/calculatorclient"
+)
+
+var calcCommands = map[string]command{
+	"sum": {
+		usage: "A B",
+		help:  "Add two numbers (Sum).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) != 2 {
+					return usagef("want two numbers")
+				}
+				a, err := parseInt(args[0], 32)
+				if err != nil {
+					return err
+				}
+				b, err := parseInt(args[1], 32)
+				if err != nil {
+					return err
+				}
+				sum, err := calculatorclient.New(e.cc).Sum(ctx, int32(a), int32(b))
+				if err != nil {
+					return err
+				}
+				return e.out.print(numberRow("SUM", "sum", sum))
+			}
+		},
+	},
+	"primes": {
+		usage: "N",
+		help:  "List the prime factors of a number as the server finds them (PrimeNumberDecompsition).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) != 1 {
+					return usagef("want one number")
+				}
+				n, err := parseInt(args[0], 64)
+				if err != nil {
+					return err
+				}
+				e.out.live = true
+				it := calculatorclient.New(e.cc).PrimeFactors(ctx, n)
+				defer it.Close()
+				for it.Next() {
+					if err := e.out.print(numberRow("FACTOR", "prime_factor", it.Value())); err != nil {
+						return err
+					}
+				}
+				return it.Err()
+			}
+		},
+	},
+	"average": {
+		usage: "[N...]",
+		help:  "Average numbers given as arguments or, without any, read from stdin (ComputeAverage).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				numbers, err := readNumbers(args, e.stdin)
+				if err != nil {
+					return err
+				}
+				if len(numbers) == 0 {
+					return usagef("want at least one number")
+				}
+				avg, err := calculatorclient.New(e.cc).Average(ctx, numbers)
+				if err != nil {
+					return err
+				}
+				return e.out.print(numberRow("AVERAGE", "average", avg))
+			}
+		},
+	},
+	"sqrt": {
+		usage: "N",
+		help:  "Take the square root of a number (SquareRoot).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) != 1 {
+					return usagef("want one number")
+				}
+				n, err := parseInt(args[0], 32)
+				if err != nil {
+					return err
+				}
+				root, err := calculatorclient.New(e.cc).SquareRoot(ctx, int32(n))
+				if err != nil {
+					return err
+				}
+				return e.out.print(numberRow("ROOT", "number_root", root))
+			}
+		},
+	},
+}
+
+func numberRow(header, key string, n interface{}) row {
+	return row{
+		header: []string{header},
+		cells:  []string{fmt.Sprint(n)},
+		value:  map[string]interface{}{key: n},
+	}
+}
+
+func parseInt(s string, bits int) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, bits)
+	if err != nil {
+		return 0, usagef("%q is not a %d-bit integer", s, bits)
+	}
+	return n, nil
+}
+
+// readNumbers parses args, or the whitespace-separated words of r if
+// there are none.
+func readNumbers(args []string, r io.Reader) ([]int64, error) {
+	if len(args) == 0 {
+		sc := bufio.NewScanner(r)
+		sc.Split(bufio.ScanWords)
+		for sc.Scan() {
+			args = append(args, sc.Text())
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+	}
+	numbers := make([]int64, 0, len(args))
+	for _, arg := range args {
+		n, err := parseInt(arg, 64)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/shivkumar123g/grpc_go_course/greet/greetclient"
+)
+
+var greetCommands = map[string]command{
+	"hello": {
+		usage: "FIRST [LAST]",
+		help:  "Greet a person (Greet).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				p, err := personArgs(args)
+				if err != nil {
+					return err
+				}
+				result, err := greetclient.New(e.cc).Greet(ctx, p.FirstName, p.LastName)
+				if err != nil {
+					return err
+				}
+				return e.out.print(greetingRow(result))
+			}
+		},
+	},
+	"slow": {
+		usage: "FIRST [LAST]",
+		help:  "Greet a person after three seconds (GreetWithDeadline); try it with -timeout.",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				p, err := personArgs(args)
+				if err != nil {
+					return err
+				}
+				result, err := greetclient.New(e.cc).GreetWithDeadline(ctx, p.FirstName, p.LastName)
+				if err != nil {
+					return err
+				}
+				return e.out.print(greetingRow(result))
+			}
+		},
+	},
+	"many": {
+		usage: "FIRST [LAST]",
+		help:  "Receive greetings for a person as the server streams them (GreetManyTimes).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				p, err := personArgs(args)
+				if err != nil {
+					return err
+				}
+				e.out.live = true
+				it := greetclient.New(e.cc).GreetManyTimes(ctx, p.FirstName, p.LastName)
+				defer it.Close()
+				for it.Next() {
+					if err := e.out.print(greetingRow(it.Value())); err != nil {
+						return err
+					}
+				}
+				return it.Err()
+			}
+		},
+	},
+	"long": {
+		usage: "< names",
+		help:  "Greet everyone read from stdin, one \"FIRST [LAST]\" per line, in one reply (LongGreet).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) > 0 {
+					return usagef("long reads names from stdin and takes no arguments")
+				}
+				people, err := readPeople(e.stdin)
+				if err != nil {
+					return err
+				}
+				result, err := greetclient.New(e.cc).LongGreet(ctx, people)
+				if err != nil {
+					return err
+				}
+				return e.out.print(greetingRow(result))
+			}
+		},
+	},
+	"everyone": {
+		usage: "< names",
+		help:  "Greet everyone read from stdin, one \"FIRST [LAST]\" per line, as they come (GreetEveryone).",
+		flags: func(fs *flag.FlagSet) func(context.Context, *env, []string) error {
+			return func(ctx context.Context, e *env, args []string) error {
+				if len(args) > 0 {
+					return usagef("everyone reads names from stdin and takes no arguments")
+				}
+				e.out.live = true
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				stream, err := greetclient.New(e.cc).GreetEveryone(ctx)
+				if err != nil {
+					return err
+				}
+				sent := make(chan error, 1)
+				go func() {
+					sent <- eachPerson(e.stdin, func(p greetclient.Person) error {
+						return stream.Send(p.FirstName, p.LastName)
+					})
+					stream.CloseSend()
+				}()
+				for {
+					result, err := stream.Recv()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						return err
+					}
+					if err := e.out.print(greetingRow(strings.TrimSpace(result))); err != nil {
+						return err
+					}
+				}
+				return <-sent
+			}
+		},
+	},
+}
+
+func greetingRow(result string) row {
+	return row{
+		header: []string{"RESULT"},
+		cells:  []string{result},
+		value:  map[string]string{"result": result},
+	}
+}
+
+func personArgs(args []string) (greetclient.Person, error) {
+	switch len(args) {
+	case 1:
+		return greetclient.Person{FirstName: args[0]}, nil
+	case 2:
+		return greetclient.Person{FirstName: args[0], LastName: args[1]}, nil
+	}
+	return greetclient.Person{}, usagef("want a first name and optionally a last name")
+}
+
+// eachPerson calls fn with every "FIRST [LAST]" line of r, skipping blank
+// lines.
+func eachPerson(r io.Reader, fn func(greetclient.Person) error) error {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		p := greetclient.Person{FirstName: fields[0], LastName: strings.Join(fields[1:], " ")}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+func readPeople(r io.Reader) ([]greetclient.Person, error) {
+	var people []greetclient.Person
+	err := eachPerson(r, func(p greetclient.Person) error {
+		people = append(people, p)
+		return nil
+	})
+	return people, err
+}
@@ -0,0 +1,253 @@
+// grpcctl calls the RPCs of the greet, calculator and blog services from
+// the command line:
+//
+//	grpcctl greet hello Ann
+//	grpcctl greet everyone < names.txt
+//	grpcctl calc sqrt 25
+//	grpcctl blog create --author 1 --title "First post" --content "..."
+//	grpcctl blog list --author 1 -o json
+//
+// Run grpcctl, grpcctl <service> or grpcctl <service> <command> -h for
+// the commands and their flags. The connection flags (-target, -tls, ...)
+// and -o go with any command; GRPCCTL_TARGET and GRPCCTL_TOKEN set the
+// target and bearer token too, and GRPCCTL_TRACING_EXPORTER exports
+// traces, see package config.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/client"
+	"github.com/shivkumar123g/grpc_go_course/tracing"
+	"google.golang.org/grpc"
+)
+
+// command is a subcommand of a service.
+type command struct {
+	usage string // arguments after the command name
+	help  string
+	// flags registers the command's own flags and returns the function
+	// running it with the remaining arguments.
+	flags func(fs *flag.FlagSet) func(ctx context.Context, env *env, args []string) error
+}
+
+// services maps service names to their commands.
+var services = map[string]map[string]command{
+	"greet": greetCommands,
+	"calc":  calcCommands,
+	"blog":  blogCommands,
+}
+
+// env is what commands run with.
+type env struct {
+	conn  connFlags
+	out   *printer
+	stdin io.Reader
+	cc    *grpc.ClientConn
+}
+
+// connFlags are the connection settings shared by every command.
+type connFlags struct {
+	target     string
+	tls        bool
+	caFile     string
+	certFile   string
+	keyFile    string
+	serverName string
+	token      string
+	timeout    time.Duration
+	wait       bool
+	format     string
+}
+
+func (c *connFlags) register(fs *flag.FlagSet) {
+	target := os.Getenv("GRPCCTL_TARGET")
+	if target == "" {
+		target = "localhost:50051"
+	}
+	fs.StringVar(&c.target, "target", target, "server address")
+	fs.BoolVar(&c.tls, "tls", false, "connect over TLS")
+	fs.StringVar(&c.caFile, "ca", "", "CA file verifying the server, e.g. ssl/ca.crt; implies -tls")
+	fs.StringVar(&c.certFile, "cert", "", "client certificate for mutual TLS; implies -tls")
+	fs.StringVar(&c.keyFile, "key", "", "client certificate key")
+	fs.StringVar(&c.serverName, "server-name", "", "name the server certificate must match, if not the target host")
+	fs.StringVar(&c.token, "token", os.Getenv("GRPCCTL_TOKEN"), "bearer token, see auth/auth_token")
+	fs.DurationVar(&c.timeout, "timeout", 10*time.Second, "deadline of unary calls; streams run until done or interrupted")
+	fs.BoolVar(&c.wait, "wait", false, "wait for an unreachable server instead of failing, up to -timeout for unary calls")
+	fs.StringVar(&c.format, "o", "table", "output format: table, json or yaml")
+}
+
+func (c *connFlags) options() client.Options {
+	opts := client.Options{Token: c.token, Timeout: c.timeout, WaitForReady: c.wait}
+	if c.tls || c.caFile != "" || c.certFile != "" {
+		opts.TLS = &client.TLS{CAFile: c.caFile, CertFile: c.certFile, KeyFile: c.keyFile, ServerName: c.serverName}
+	}
+	return opts
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		usage(os.Stderr)
+		return 2
+	}
+	commands, ok := services[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown service %q\n\n", args[0])
+		usage(os.Stderr)
+		return 2
+	}
+	if len(args) < 2 {
+		serviceUsage(os.Stderr, args[0], commands)
+		return 2
+	}
+	cmd, ok := commands[args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", args[1])
+		serviceUsage(os.Stderr, args[0], commands)
+		return 2
+	}
+
+	name := args[0] + " " + args[1]
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: grpcctl %s\n\n%s\n\nFlags:\n", strings.TrimSpace(name+" [flags] "+cmd.usage), cmd.help)
+		fs.PrintDefaults()
+	}
+	e := &env{stdin: os.Stdin}
+	e.conn.register(fs)
+	runCmd := cmd.flags(fs)
+	if err := parseInterspersed(fs, args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	out, err := newPrinter(os.Stdout, e.conn.format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	e.out = out
+
+	// Ctrl+C ends streams cleanly.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	flushTraces, err := tracing.SetupClient(ctx, "grpcctl")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to set up tracing: %v\n", err)
+		return 1
+	}
+	defer flushTraces(context.Background())
+
+	e.cc, err = client.Dial(ctx, e.conn.target, e.conn.options())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer e.cc.Close()
+
+	err = runCmd(ctx, e, fs.Args())
+	if flushErr := e.out.flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		printError(os.Stderr, err)
+		var usageErr usageError
+		if errors.As(err, &usageErr) {
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+// parseInterspersed parses flags that may come before, between or after
+// the positional arguments, which stay in order in fs.Args(). Arguments
+// after "--" are all positional, e.g. negative numbers.
+func parseInterspersed(fs *flag.FlagSet, args []string) error {
+	var rest []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, rest = args[:i], args[i+1:]
+			break
+		}
+	}
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	return fs.Parse(append(append([]string{"--"}, positional...), rest...))
+}
+
+// usageError is a mistake in the command line.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+func usagef(format string, args ...interface{}) error {
+	return usageError(fmt.Sprintf(format, args...))
+}
+
+func printError(w io.Writer, err error) {
+	fmt.Fprintf(w, "Error: %v\n", err)
+	var rpcErr *client.Error
+	if errors.As(err, &rpcErr) {
+		for _, v := range rpcErr.Violations {
+			fmt.Fprintf(w, "  %s: %s\n", v.Field, v.Description)
+		}
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: grpcctl <service> <command> [flags] [args]")
+	fmt.Fprintln(w)
+	for _, name := range sortedKeys(services) {
+		fmt.Fprintf(w, "%s commands:\n", name)
+		printCommands(w, services[name])
+	}
+	fmt.Fprintln(w, "Run grpcctl <service> <command> -h for the flags of a command.")
+}
+
+func serviceUsage(w io.Writer, service string, commands map[string]command) {
+	fmt.Fprintf(w, "Usage: grpcctl %s <command> [flags] [args]\n\nCommands:\n", service)
+	printCommands(w, commands)
+}
+
+func printCommands(w io.Writer, commands map[string]command) {
+	for _, name := range sortedKeys(commands) {
+		fmt.Fprintf(w, "  %-13s %s\n", name, strings.SplitN(commands[name].help, "\n", 2)[0])
+	}
+	fmt.Fprintln(w)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// row is one item of output: the cells of the table format and the value
+// the json and yaml formats encode, a proto.Message or plain values.
+type row struct {
+	header []string
+	cells  []string
+	value  interface{}
+}
+
+// printer writes rows in the chosen format. Tables are aligned when
+// flushed, unless live, when every row goes out as it comes. JSON is one
+// document per line, YAML a stream of documents.
+type printer struct {
+	format string
+	live   bool
+	w      io.Writer
+	tw     *tabwriter.Writer
+	header string
+	yaml   *yaml.Encoder
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	p := &printer{format: format, w: w}
+	switch format {
+	case "table":
+		p.tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	case "json":
+	case "yaml":
+		p.yaml = yaml.NewEncoder(w)
+		p.yaml.SetIndent(2)
+	default:
+		return nil, fmt.Errorf("unknown output format %q, want table, json or yaml", format)
+	}
+	return p, nil
+}
+
+func (p *printer) print(r row) error {
+	switch p.format {
+	case "table":
+		if header := strings.Join(r.header, "\t"); header != p.header {
+			p.header = header
+			fmt.Fprintln(p.tw, header)
+		}
+		fmt.Fprintln(p.tw, strings.Join(r.cells, "\t"))
+		if p.live {
+			return p.tw.Flush()
+		}
+		return nil
+	case "json":
+		v, err := plain(r.value)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.w, "%s\n", data)
+		return err
+	default:
+		v, err := plain(r.value)
+		if err != nil {
+			return err
+		}
+		return p.yaml.Encode(v)
+	}
+}
+
+func (p *printer) flush() error {
+	switch p.format {
+	case "table":
+		return p.tw.Flush()
+	case "yaml":
+		return p.yaml.Close()
+	}
+	return nil
+}
+
+// plain turns messages into maps, keyed by the field names of the
+// .proto files like the server's payload logs.
+func plain(v interface{}) (interface{}, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return v, nil
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,259 @@
+// Package logging writes structured JSON logs with log/slog and logs
+// every gRPC call through server interceptors: method, peer, duration,
+// status code and a request ID, plus optionally the messages, with
+// sensitive fields redacted.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/certs"
+	"github.com/shivkumar123g/grpc_go_course/config"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// RequestIDHeader is the metadata key carrying the request ID. A caller
+// may send one; otherwise it is generated. Either way the server returns
+// it in the response header, or in the trailer of calls that fail before
+// responding; a separate header would keep clients from retrying those.
+const RequestIDHeader = "x-request-id"
+
+// redacted replaces the value of redacted fields.
+const redacted = "[REDACTED]"
+
+// New returns a logger writing JSON lines to w at the level cfg sets.
+func New(w io.Writer, cfg config.Logging) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx carrying logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of the current call, which tags every
+// record with the call's method and request ID, or the default logger
+// outside of calls.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// Interceptors log the calls a server handles.
+type Interceptors struct {
+	logger   *slog.Logger
+	payloads bool
+	redact   map[string]bool
+}
+
+// NewInterceptors returns interceptors logging to logger as cfg says.
+func NewInterceptors(logger *slog.Logger, cfg config.Logging) *Interceptors {
+	i := &Interceptors{logger: logger, payloads: cfg.Payloads, redact: make(map[string]bool)}
+	for _, field := range cfg.Redact {
+		i.redact[field] = true
+	}
+	return i
+}
+
+// start prepares the logger of a call and returns it with the context
+// carrying it and the call's request ID as metadata.
+func (i *Interceptors) start(ctx context.Context, method string) (context.Context, *slog.Logger, metadata.MD) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := ""
+	if values := md.Get(RequestIDHeader); len(values) > 0 && len(values[0]) <= 128 {
+		requestID = values[0]
+	} else {
+		requestID = newRequestID()
+	}
+
+	attrs := []interface{}{slog.String("method", method), slog.String("request_id", requestID)}
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+	if subject, ok := certs.ClientSubject(ctx); ok {
+		attrs = append(attrs, slog.String("client_subject", subject.String()))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+	}
+	logger := i.logger.With(attrs...)
+	return NewContext(ctx, logger), logger, metadata.Pairs(RequestIDHeader, requestID)
+}
+
+// finish logs the outcome of a call at a level that fits its code.
+func (i *Interceptors) finish(ctx context.Context, logger *slog.Logger, start time.Time, err error, attrs ...interface{}) {
+	st, _ := status.FromError(err)
+	attrs = append(attrs,
+		slog.Float64("duration_ms", float64(time.Since(start))/float64(time.Millisecond)),
+		slog.String("code", st.Code().String()),
+	)
+	if err != nil {
+		attrs = append(attrs, slog.String("error", st.Message()))
+	}
+	logger.Log(ctx, levelFor(st.Code()), "finished call", attrs...)
+}
+
+// levelFor rates a status code: caller mistakes are warnings, server
+// failures errors.
+func levelFor(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unimplemented, codes.Unavailable, codes.DeadlineExceeded:
+		return slog.LevelError
+	}
+	return slog.LevelWarn
+}
+
+// payload logs a message at debug level if payload logging is on.
+func (i *Interceptors) payload(ctx context.Context, logger *slog.Logger, msg string, m interface{}) {
+	if !i.payloads || !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	pm, ok := m.(proto.Message)
+	if !ok {
+		return
+	}
+	logger.DebugContext(ctx, msg, slog.Any("payload", i.redactMessage(pm)))
+}
+
+// redactMessage returns m as JSON values with the redacted fields
+// replaced, at any depth.
+func (i *Interceptors) redactMessage(m proto.Message) interface{} {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return err.Error()
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err.Error()
+	}
+	return i.redactValue(v)
+}
+
+func (i *Interceptors) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if i.redact[key] {
+				v[key] = redacted
+			} else {
+				v[key] = i.redactValue(value)
+			}
+		}
+	case []interface{}:
+		for n, value := range v {
+			v[n] = i.redactValue(value)
+		}
+	}
+	return v
+}
+
+// UnaryServerInterceptor logs unary calls.
+func (i *Interceptors) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx, logger, requestID := i.start(ctx, info.FullMethod)
+		i.payload(ctx, logger, "request", req)
+		resp, err := handler(ctx, req)
+		if err == nil {
+			grpc.SetHeader(ctx, requestID)
+			i.payload(ctx, logger, "response", resp)
+		} else {
+			grpc.SetTrailer(ctx, requestID)
+		}
+		i.finish(ctx, logger, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor logs streaming calls with their message counts.
+func (i *Interceptors) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, logger, requestID := i.start(ss.Context(), info.FullMethod)
+		stream := &serverStream{ServerStream: ss, ctx: ctx, logger: logger, i: i, requestID: requestID}
+		err := handler(srv, stream)
+		if !stream.responded {
+			ss.SetTrailer(requestID)
+		}
+		i.finish(ctx, logger, start, err,
+			slog.Int("msgs_received", stream.received),
+			slog.Int("msgs_sent", stream.sent),
+		)
+		return err
+	}
+}
+
+// serverStream carries the call's logger in its context and logs the
+// messages passing through it. It adds the request ID to the header when
+// the header goes out.
+type serverStream struct {
+	grpc.ServerStream
+	ctx            context.Context
+	logger         *slog.Logger
+	i              *Interceptors
+	requestID      metadata.MD
+	responded      bool
+	sent, received int
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendHeader(md metadata.MD) error {
+	s.responded = true
+	return s.ServerStream.SendHeader(metadata.Join(md, s.requestID))
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	if !s.responded {
+		s.responded = true
+		s.ServerStream.SetHeader(s.requestID)
+	}
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+		s.i.payload(s.ctx, s.logger, "sent message", m)
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received++
+		s.i.payload(s.ctx, s.logger, "received message", m)
+	}
+	return err
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,256 @@
+// Package metrics records Prometheus metrics for gRPC calls through
+// server and client interceptors: calls started and handled by status
+// code, call latency and, for streams, messages sent and received.
+//
+// Every metric is labelled with grpc_service, grpc_method and grpc_type
+// (unary, client_stream, server_stream or bidi_stream); the handled
+// counters also carry grpc_code.
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var labels = []string{"grpc_service", "grpc_method", "grpc_type"}
+
+// metrics holds the collectors of one side of a call, "server" or
+// "client".
+type metrics struct {
+	started  *prometheus.CounterVec
+	handled  *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	received *prometheus.CounterVec
+	sent     *prometheus.CounterVec
+}
+
+func newMetrics(reg prometheus.Registerer, side string) *metrics {
+	m := &metrics{
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_" + side + "_started_total",
+			Help: "RPCs started on the " + side + ".",
+		}, labels),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_" + side + "_handled_total",
+			Help: "RPCs completed on the " + side + ", by status code.",
+		}, append(labels, "grpc_code")),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_" + side + "_handling_seconds",
+			Help:    "Time from the start of an RPC to its completion on the " + side + ".",
+			Buckets: prometheus.DefBuckets,
+		}, labels),
+		received: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_" + side + "_msg_received_total",
+			Help: "Stream messages received by the " + side + ".",
+		}, labels),
+		sent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_" + side + "_msg_sent_total",
+			Help: "Stream messages sent by the " + side + ".",
+		}, labels),
+	}
+	reg.MustRegister(m.started, m.handled, m.latency, m.received, m.sent)
+	return m
+}
+
+// call tracks one RPC from start to completion.
+type call struct {
+	m      *metrics
+	labels []string
+	start  time.Time
+}
+
+func (m *metrics) start(fullMethod, typ string) *call {
+	service, method := splitMethod(fullMethod)
+	c := &call{m: m, labels: []string{service, method, typ}, start: time.Now()}
+	m.started.WithLabelValues(c.labels...).Inc()
+	return c
+}
+
+func (c *call) done(err error) {
+	code := status.Code(err).String()
+	c.m.handled.WithLabelValues(append(c.labels, code)...).Inc()
+	c.m.latency.WithLabelValues(c.labels...).Observe(time.Since(c.start).Seconds())
+}
+
+func (c *call) received() {
+	c.m.received.WithLabelValues(c.labels...).Inc()
+}
+
+func (c *call) sent() {
+	c.m.sent.WithLabelValues(c.labels...).Inc()
+}
+
+// splitMethod splits "/pkg.Service/Method" into its service and method.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
+func streamType(clientStreams, serverStreams bool) string {
+	switch {
+	case clientStreams && serverStreams:
+		return "bidi_stream"
+	case clientStreams:
+		return "client_stream"
+	case serverStreams:
+		return "server_stream"
+	}
+	return "unary"
+}
+
+// ServerMetrics records the calls a server handles.
+type ServerMetrics struct {
+	m *metrics
+}
+
+// NewServerMetrics registers the server metrics with reg.
+func NewServerMetrics(reg prometheus.Registerer) *ServerMetrics {
+	return &ServerMetrics{m: newMetrics(reg, "server")}
+}
+
+// UnaryServerInterceptor records unary calls.
+func (sm *ServerMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		c := sm.m.start(info.FullMethod, "unary")
+		resp, err := handler(ctx, req)
+		c.done(err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records streaming calls and their messages.
+func (sm *ServerMetrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		c := sm.m.start(info.FullMethod, streamType(info.IsClientStream, info.IsServerStream))
+		err := handler(srv, &serverStream{ServerStream: ss, call: c})
+		c.done(err)
+		return err
+	}
+}
+
+// serverStream counts the messages of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	call *call
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.call.sent()
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.call.received()
+	}
+	return err
+}
+
+// ClientMetrics records the calls a client makes.
+type ClientMetrics struct {
+	m *metrics
+}
+
+// NewClientMetrics registers the client metrics with reg.
+func NewClientMetrics(reg prometheus.Registerer) *ClientMetrics {
+	return &ClientMetrics{m: newMetrics(reg, "client")}
+}
+
+// UnaryClientInterceptor records unary calls.
+func (cm *ClientMetrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		c := cm.m.start(method, "unary")
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		c.done(err)
+		return err
+	}
+}
+
+// StreamClientInterceptor records streaming calls and their messages. A
+// call is complete when receiving from it fails, io.EOF counting as OK.
+func (cm *ClientMetrics) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		c := cm.m.start(method, streamType(desc.ClientStreams, desc.ServerStreams))
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			c.done(err)
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, call: c, serverStreams: desc.ServerStreams}, nil
+	}
+}
+
+// clientStream counts the messages of a grpc.ClientStream.
+type clientStream struct {
+	grpc.ClientStream
+	call *call
+	// serverStreams tells whether the response is a stream, which ends
+	// with io.EOF, or a single message.
+	serverStreams bool
+	finished      bool
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.call.sent()
+	}
+	return err
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		s.call.received()
+		if !s.serverStreams {
+			s.finish(nil)
+		}
+	case err == io.EOF:
+		s.finish(nil)
+	default:
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *clientStream) finish(err error) {
+	if !s.finished {
+		s.finished = true
+		s.call.done(err)
+	}
+}
+
+// NewRegistry returns a registry with the Go runtime and process
+// collectors registered.
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
+
+// Handler serves the metrics gathered by reg at /metrics.
+func Handler(reg prometheus.Gatherer) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	return mux
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// bucket is a token bucket holding up to burst tokens, refilled at rate
+// tokens per second. It starts full.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newBucket returns a full bucket. A burst of zero is rate rounded up.
+func newBucket(rate float64, burst int, t time.Time) *bucket {
+	size := float64(burst)
+	if burst <= 0 {
+		size = math.Ceil(rate)
+	}
+	return &bucket{rate: rate, burst: size, tokens: size, last: t}
+}
+
+func (b *bucket) refill(t time.Time) {
+	if t.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+t.Sub(b.last).Seconds()*b.rate)
+		b.last = t
+	}
+}
+
+// take removes a token if there is one. Otherwise it returns how long
+// until there is.
+func (b *bucket) take(t time.Time) time.Duration {
+	b.refill(t)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return b.until(1 - b.tokens)
+}
+
+// reserve removes a token even if there is none, going into debt, and
+// returns how long until the debt is paid off.
+func (b *bucket) reserve(t time.Time) time.Duration {
+	b.refill(t)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return b.until(-b.tokens)
+}
+
+// full reports whether the bucket has refilled completely by t.
+func (b *bucket) full(t time.Time) bool {
+	return b.tokens+t.Sub(b.last).Seconds()*b.rate >= b.burst
+}
+
+// until returns how long refilling n tokens takes.
+func (b *bucket) until(n float64) time.Duration {
+	return time.Duration(math.Ceil(n / b.rate * float64(time.Second)))
+}
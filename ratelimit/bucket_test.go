@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketTake(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	b := newBucket(2, 0, t0)
+	for i := 0; i < 2; i++ {
+		if wait := b.take(t0); wait != 0 {
+			t.Fatalf("take %d of a full bucket waits %v", i+1, wait)
+		}
+	}
+	tests := []struct {
+		after time.Duration
+		want  time.Duration
+	}{
+		{0, 500 * time.Millisecond},
+		{250 * time.Millisecond, 250 * time.Millisecond},
+		{500 * time.Millisecond, 0},
+		{600 * time.Millisecond, 400 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if wait := b.take(t0.Add(tt.after)); wait != tt.want {
+			t.Errorf("take after %v waits %v, want %v", tt.after, wait, tt.want)
+		}
+	}
+}
+
+func TestBucketBurst(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	tests := []struct {
+		rate  float64
+		burst int
+		want  float64
+	}{
+		{2, 0, 2},
+		{0.5, 0, 1},
+		{2.5, 0, 3},
+		{2, 5, 5},
+	}
+	for _, tt := range tests {
+		if b := newBucket(tt.rate, tt.burst, t0); b.tokens != tt.want || b.burst != tt.want {
+			t.Errorf("newBucket(%v, %d) holds %v of %v tokens, want %v", tt.rate, tt.burst, b.tokens, b.burst, tt.want)
+		}
+	}
+
+	// A long idle time refills no more than the burst.
+	b := newBucket(1, 3, t0)
+	for i := 0; i < 3; i++ {
+		b.take(t0)
+	}
+	if b.full(t0.Add(2 * time.Second)) {
+		t.Errorf("full after refilling 2 of 3 tokens")
+	}
+	if !b.full(t0.Add(3 * time.Second)) {
+		t.Errorf("not full after refilling 3 tokens")
+	}
+	b.refill(t0.Add(time.Hour))
+	if b.tokens != 3 {
+		t.Errorf("holds %v tokens after an hour, want the burst of 3", b.tokens)
+	}
+}
+
+func TestBucketReserve(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	b := newBucket(4, 1, t0)
+	for i, want := range []time.Duration{0, 250 * time.Millisecond, 500 * time.Millisecond} {
+		if wait := b.reserve(t0); wait != want {
+			t.Errorf("reserve %d waits %v, want %v", i+1, wait, want)
+		}
+	}
+	// The debt is paid off before take succeeds again.
+	if wait := b.take(t0.Add(250 * time.Millisecond)); wait != 500*time.Millisecond {
+		t.Errorf("take while in debt waits %v, want 500ms", wait)
+	}
+	if wait := b.take(t0.Add(750 * time.Millisecond)); wait != 0 {
+		t.Errorf("take after paying off the debt waits %v", wait)
+	}
+}
@@ -0,0 +1,217 @@
+// Package ratelimit keeps any one caller from taking over the server. Its
+// interceptors give every caller a token bucket per method, cap the
+// streams a caller may have open and slow down callers streaming messages
+// too fast. Calls over quota fail with RESOURCE_EXHAUSTED and tell the
+// caller how long to wait before trying again.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"github.com/shivkumar123g/grpc_go_course/certs"
+	"github.com/shivkumar123g/grpc_go_course/config"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryAfterHeader is the trailer of rejected calls holding the number of
+// seconds to wait before trying again. The same delay is sent in
+// milliseconds as grpc-retry-pushback-ms, which gRPC clients with a retry
+// policy honour, and as a RetryInfo error detail.
+const RetryAfterHeader = "retry-after"
+
+const pushbackHeader = "grpc-retry-pushback-ms"
+
+// streamRetryDelay is the wait suggested to callers over a stream cap, as
+// there is no telling when one of their streams will end.
+const streamRetryDelay = time.Second
+
+// sweepInterval is how often the buckets of callers that have been idle
+// long enough to be full again are dropped.
+const sweepInterval = time.Minute
+
+// Limiter holds the quotas of every caller.
+type Limiter struct {
+	cfg config.RateLimit
+
+	mu      sync.Mutex
+	buckets map[key]*bucket
+	// streams counts open streams by caller and method, and by caller
+	// alone under the empty method.
+	streams   map[key]int
+	nextSweep time.Time
+}
+
+type key struct {
+	caller, method string
+}
+
+// New returns a Limiter enforcing cfg.
+func New(cfg config.RateLimit) *Limiter {
+	return &Limiter{
+		cfg:     cfg,
+		buckets: make(map[key]*bucket),
+		streams: make(map[key]int),
+	}
+}
+
+// limit returns the quota of every caller on method.
+func (l *Limiter) limit(method string) config.Limit {
+	if limit, ok := l.cfg.Methods[method]; ok {
+		return limit
+	}
+	return l.cfg.Default
+}
+
+// allow takes a call from caller's bucket for method. When the bucket is
+// empty it returns how long until it is not.
+func (l *Limiter) allow(caller, method string, limit config.Limit) time.Duration {
+	if limit.Rate <= 0 {
+		return 0
+	}
+	t := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if t.After(l.nextSweep) {
+		for k, b := range l.buckets {
+			if b.full(t) {
+				delete(l.buckets, k)
+			}
+		}
+		l.nextSweep = t.Add(sweepInterval)
+	}
+	k := key{caller: caller, method: method}
+	b, ok := l.buckets[k]
+	if !ok {
+		b = newBucket(limit.Rate, limit.Burst, t)
+		l.buckets[k] = b
+	}
+	return b.take(t)
+}
+
+// openStream counts a new stream of caller on method, unless it would
+// take the caller over a cap. The returned function closes the stream.
+func (l *Limiter) openStream(caller, method string, limit config.Limit) (func(), bool) {
+	if l.cfg.MaxStreams <= 0 && limit.MaxStreams <= 0 {
+		return func() {}, true
+	}
+	total, perMethod := key{caller: caller}, key{caller: caller, method: method}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if (l.cfg.MaxStreams > 0 && l.streams[total] >= l.cfg.MaxStreams) ||
+		(limit.MaxStreams > 0 && l.streams[perMethod] >= limit.MaxStreams) {
+		return nil, false
+	}
+	l.streams[total]++
+	l.streams[perMethod]++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for _, k := range []key{total, perMethod} {
+			if l.streams[k]--; l.streams[k] <= 0 {
+				delete(l.streams, k)
+			}
+		}
+	}, true
+}
+
+// UnaryServerInterceptor rejects unary calls over the caller's quota.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		limit := l.limit(info.FullMethod)
+		if wait := l.allow(caller(ctx), info.FullMethod, limit); wait > 0 {
+			return nil, exhausted(func(md metadata.MD) { grpc.SetTrailer(ctx, md) }, wait,
+				"Rate limit of %s exceeded, retry in %v", info.FullMethod, wait.Round(time.Millisecond))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streaming calls over the caller's quota
+// or stream caps, and slows down the messages the caller sends when the
+// method has a message rate.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		who := caller(ss.Context())
+		limit := l.limit(info.FullMethod)
+		if wait := l.allow(who, info.FullMethod, limit); wait > 0 {
+			return exhausted(ss.SetTrailer, wait,
+				"Rate limit of %s exceeded, retry in %v", info.FullMethod, wait.Round(time.Millisecond))
+		}
+		closeStream, ok := l.openStream(who, info.FullMethod, limit)
+		if !ok {
+			return exhausted(ss.SetTrailer, streamRetryDelay, "Too many open streams")
+		}
+		defer closeStream()
+		if info.IsClientStream && limit.MessageRate > 0 {
+			ss = &serverStream{ServerStream: ss, bucket: newBucket(limit.MessageRate, 0, time.Now())}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// serverStream holds back received messages to keep to a message rate.
+// Waiting stops reading from the connection, so flow control slows the
+// sender down in turn.
+type serverStream struct {
+	grpc.ServerStream
+	bucket *bucket
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if wait := s.bucket.reserve(time.Now()); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-s.Context().Done():
+			return status.FromContextError(s.Context().Err()).Err()
+		case <-timer.C:
+		}
+	}
+	return s.ServerStream.RecvMsg(m)
+}
+
+// exhausted returns the RESOURCE_EXHAUSTED error of a rejected call,
+// sending the delay to retry after in the trailer set by setTrailer.
+func exhausted(setTrailer func(metadata.MD), wait time.Duration, format string, a ...interface{}) error {
+	setTrailer(metadata.Pairs(
+		pushbackHeader, strconv.FormatInt(int64(math.Ceil(float64(wait)/float64(time.Millisecond))), 10),
+		RetryAfterHeader, strconv.FormatInt(int64(math.Ceil(wait.Seconds())), 10),
+	))
+	st := status.Newf(codes.ResourceExhausted, format, a...)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// caller names whoever makes the call: the subject of their bearer token,
+// else that of their client certificate, else their IP address. Tokens
+// are only seen when the auth interceptors run first.
+func caller(ctx context.Context) string {
+	if id, ok := auth.FromContext(ctx); ok {
+		return "subject:" + id.Subject
+	}
+	if subject, ok := certs.ClientSubject(ctx); ok {
+		return "cert:" + subject.String()
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		addr := p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+		return "addr:" + addr
+	}
+	return ""
+}
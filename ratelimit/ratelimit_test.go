@@ -0,0 +1,177 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"github.com/shivkumar123g/grpc_go_course/config"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// transportStream records the trailer a unary handler sets.
+type transportStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// serverStreamStub is the stream of a streaming call, recording its
+// trailer.
+type serverStreamStub struct {
+	grpc.ServerStream
+	ctx     context.Context
+	trailer metadata.MD
+}
+
+func (s *serverStreamStub) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStreamStub) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+func as(subject string) context.Context {
+	return auth.NewContext(context.Background(), auth.Identity{Subject: subject})
+}
+
+// wantExhausted checks that err rejects a call for about wait and that
+// trailer tells the caller so.
+func wantExhausted(t *testing.T, err error, trailer metadata.MD, wait time.Duration) {
+	t.Helper()
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("got %v, want RESOURCE_EXHAUSTED", err)
+	}
+	var delay time.Duration
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			delay = info.GetRetryDelay().AsDuration()
+		}
+	}
+	if delay <= 0 || delay > wait {
+		t.Errorf("RetryInfo delay is %v, want up to %v", delay, wait)
+	}
+	// The trailers round the delay up, to milliseconds and to seconds.
+	if got := trailer.Get(pushbackHeader); len(got) != 1 || got[0] != strconv.FormatInt(int64(math.Ceil(float64(delay)/float64(time.Millisecond))), 10) {
+		t.Errorf("trailer %s = %v, want %v in milliseconds", pushbackHeader, got, delay)
+	}
+	if got := trailer.Get(RetryAfterHeader); len(got) != 1 || got[0] != "1" {
+		t.Errorf("trailer %s = %v, want 1", RetryAfterHeader, got)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	l := New(config.RateLimit{
+		Default: config.Limit{Rate: 1},
+		Methods: map[string]config.Limit{"/greet.GreetService/Greet": {}},
+	})
+	interceptor := l.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	call := func(ctx context.Context, method string) (*transportStream, error) {
+		sts := &transportStream{}
+		ctx = grpc.NewContextWithServerTransportStream(ctx, sts)
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return sts, err
+	}
+
+	const method = "/blog.BlogService/ReadBlog"
+	if _, err := call(as("ann"), method); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	sts, err := call(as("ann"), method)
+	wantExhausted(t, err, sts.trailer, time.Second)
+
+	// Quotas are per caller and method, and methods may have none.
+	if _, err := call(as("bob"), method); err != nil {
+		t.Errorf("other caller: %v", err)
+	}
+	if _, err := call(as("ann"), "/blog.BlogService/ListBlogsPage"); err != nil {
+		t.Errorf("other method: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := call(as("ann"), "/greet.GreetService/Greet"); err != nil {
+			t.Errorf("method without a rate: %v", err)
+		}
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	l := New(config.RateLimit{
+		MaxStreams: 2,
+		Methods: map[string]config.Limit{
+			"/blog.BlogService/WatchBlogs": {MaxStreams: 1},
+		},
+	})
+	interceptor := l.StreamServerInterceptor()
+	open := func(who, method string, handler grpc.StreamHandler) (*serverStreamStub, error) {
+		ss := &serverStreamStub{ctx: as(who)}
+		return ss, interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: method, IsServerStream: true}, handler)
+	}
+	idle := func(srv interface{}, stream grpc.ServerStream) error { return nil }
+
+	// While a watch is open, a second one is over the method's cap, and a
+	// third stream of any method over the caller's.
+	err := func() error {
+		_, err := open("ann", "/blog.BlogService/WatchBlogs", func(interface{}, grpc.ServerStream) error {
+			ss, err := open("ann", "/blog.BlogService/WatchBlogs", idle)
+			wantExhausted(t, err, ss.trailer, streamRetryDelay)
+			if _, err := open("bob", "/blog.BlogService/WatchBlogs", idle); err != nil {
+				t.Errorf("other caller: %v", err)
+			}
+			_, err = open("ann", "/blog.BlogService/ListBlog", func(interface{}, grpc.ServerStream) error {
+				ss, err := open("ann", "/blog.BlogService/SearchBlogs", idle)
+				wantExhausted(t, err, ss.trailer, streamRetryDelay)
+				return nil
+			})
+			return err
+		})
+		return err
+	}()
+	if err != nil {
+		t.Fatalf("streams under the caps: %v", err)
+	}
+
+	// Closed streams no longer count.
+	if _, err := open("ann", "/blog.BlogService/WatchBlogs", idle); err != nil {
+		t.Errorf("after the first watch ended: %v", err)
+	}
+	if len(l.streams) != 0 {
+		t.Errorf("streams left open: %v", l.streams)
+	}
+}
+
+func TestCaller(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4321}
+	withPeer := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"token", auth.NewContext(withPeer, auth.Identity{Subject: "ann"}), "subject:ann"},
+		{"address", withPeer, "addr:10.0.0.1"},
+		{"unknown", context.Background(), ""},
+	}
+	for _, tt := range tests {
+		if got := caller(tt.ctx); got != tt.want {
+			t.Errorf("%s: caller = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
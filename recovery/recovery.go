@@ -0,0 +1,51 @@
+// Package recovery keeps a panicking handler from taking the server down:
+// its interceptors turn the panic into an INTERNAL error for the caller
+// and log it with the stack trace.
+package recovery
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/shivkumar123g/grpc_go_course/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recovered converts the value of a recovered panic into the error
+// returned to the caller. The panic value itself is only logged, as it
+// may hold internal details.
+func recovered(ctx context.Context, method string, p interface{}) error {
+	logging.FromContext(ctx).Error("Recovered from panic",
+		slog.String("method", method),
+		slog.Any("panic", p),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return status.Errorf(codes.Internal, "Internal error: the server failed handling %s", method)
+}
+
+// UnaryServerInterceptor recovers from panics in unary calls.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp, err = nil, recovered(ctx, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor recovers from panics in streaming calls.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ss.Context(), info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
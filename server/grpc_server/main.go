@@ -0,0 +1,12 @@
+package main
+
+import (
+	"github.com/shivkumar123g/grpc_go_course/config"
+	"github.com/shivkumar123g/grpc_go_course/server"
+)
+
+// grpc_server serves every service on one port. Turn services off with
+// -greet=false, -calculator=false or -blog=false.
+func main() {
+	server.Main("server", config.Default())
+}
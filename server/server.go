@@ -0,0 +1,310 @@
+// Package server hosts the Greet, Calculator and Blog services on one
+// gRPC server, registering those the configuration turns on behind a
+// shared listener and interceptor chain.
+package server
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/auth"
+	"github.com/shivkumar123g/grpc_go_course/blog/blogsvc"
+	"github.com/shivkumar123g/grpc_go_course/calculator/calculatorsvc"
+	"github.com/shivkumar123g/grpc_go_course/certs"
+	"github.com/shivkumar123g/grpc_go_course/config"
+	"github.com/shivkumar123g/grpc_go_course/greet/greetsvc"
+	"github.com/shivkumar123g/grpc_go_course/logging"
+	"github.com/shivkumar123g/grpc_go_course/metrics"
+	"github.com/shivkumar123g/grpc_go_course/ratelimit"
+	"github.com/shivkumar123g/grpc_go_course/recovery"
+	"github.com/shivkumar123g/grpc_go_course/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// openServices are reachable without a bearer token even when blog auth
+// is on.
+var openServices = []string{
+	"/" + greetServiceName + "/",
+	"/" + calculatorServiceName + "/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+	"/grpc.health.v1.Health/",
+}
+
+// Service names as health checks know them.
+const (
+	greetServiceName      = "greet.GreetService"
+	calculatorServiceName = "calculator.CalculatorService"
+	blogServiceName       = "blog.BlogService"
+)
+
+// closeTimeout bounds releasing resources after the server has stopped.
+const closeTimeout = 5 * time.Second
+
+// Server is a gRPC server with the configured services registered.
+type Server struct {
+	grpc    *grpc.Server
+	health  *health.Server
+	metrics *http.Server
+	// stopMonitors ends the goroutines keeping health statuses current.
+	stopMonitors context.CancelFunc
+	// blog is nil unless the BlogService is on.
+	blog *blogsvc.Service
+}
+
+// New builds a Server from cfg, opening the resources its services need.
+func New(ctx context.Context, cfg *config.Config) (*Server, error) {
+	opts := []grpc.ServerOption{}
+	if cfg.TLS.Enabled {
+		creds, err := certs.ServerCredentials(cfg.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("loading certificates: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	// Tracing, logging and metrics come first so that calls rejected
+	// further down are seen too. Logging follows tracing to pick up the
+	// trace ID. Recovery follows them, so that a panic is recorded as the
+	// INTERNAL error it turns into.
+	registry := metrics.NewRegistry()
+	serverMetrics := metrics.NewServerMetrics(registry)
+	callLogs := logging.NewInterceptors(slog.Default(), cfg.Logging)
+	unary := []grpc.UnaryServerInterceptor{
+		tracing.UnaryServerInterceptor(),
+		callLogs.UnaryServerInterceptor(),
+		serverMetrics.UnaryServerInterceptor(),
+		recovery.UnaryServerInterceptor(),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		tracing.StreamServerInterceptor(),
+		callLogs.StreamServerInterceptor(),
+		serverMetrics.StreamServerInterceptor(),
+		recovery.StreamServerInterceptor(),
+	}
+	if cfg.Services.Blog && cfg.Blog.AuthKeyFile != "" {
+		key, err := auth.LoadKey(cfg.Blog.AuthKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading auth key: %v", err)
+		}
+		verifier := auth.NewVerifier(key, append(openServices, blogsvc.PublicMethods...)...)
+		unary = append(unary, verifier.UnaryServerInterceptor())
+		stream = append(stream, verifier.StreamServerInterceptor())
+	}
+	// Rate limits follow auth to tell callers apart by their token.
+	limiter := ratelimit.New(cfg.RateLimit)
+	unary = append(unary, limiter.UnaryServerInterceptor())
+	stream = append(stream, limiter.StreamServerInterceptor())
+	var blog *blogsvc.Service
+	if cfg.Services.Blog {
+		var err error
+		blog, err = blogsvc.Open(ctx, cfg.Blog)
+		if err != nil {
+			return nil, err
+		}
+		// Idempotency keys are scoped to the caller the verifier found.
+		unary = append(unary, blog.UnaryServerInterceptor())
+	}
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+
+	// The server as a whole ("") is SERVING for as long as it runs;
+	// each service reports its own status under its full name.
+	s := &Server{
+		grpc:    grpc.NewServer(opts...),
+		health:  health.NewServer(),
+		metrics: &http.Server{Handler: metrics.Handler(registry)},
+	}
+	if cfg.Services.Greet {
+		greetsvc.Register(s.grpc)
+		s.health.SetServingStatus(greetServiceName, healthpb.HealthCheckResponse_SERVING)
+	}
+	if cfg.Services.Calculator {
+		calculatorsvc.Register(s.grpc)
+		s.health.SetServingStatus(calculatorServiceName, healthpb.HealthCheckResponse_SERVING)
+	}
+	monitorCtx, stopMonitors := context.WithCancel(context.Background())
+	s.stopMonitors = stopMonitors
+	if blog != nil {
+		blog.Register(s.grpc)
+		s.blog = blog
+		// Not serving until the database has answered.
+		s.health.SetServingStatus(blogServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+		go blog.Monitor(monitorCtx, s.reporter(blogServiceName))
+	}
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	reflection.Register(s.grpc)
+	return s, nil
+}
+
+// reporter returns a function setting the health of service from the
+// result of a dependency check, logging every change.
+func (s *Server) reporter(service string) func(err error) {
+	var last error
+	first := true
+	return func(err error) {
+		if err != nil {
+			s.health.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+		} else {
+			s.health.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+		}
+		if err != nil && (first || last == nil) {
+			slog.Warn("Service is NOT_SERVING", "service", service, "error", err)
+		} else if err == nil && (first || last != nil) {
+			slog.Info("Service is SERVING", "service", service)
+		}
+		last, first = err, false
+	}
+}
+
+// Serve accepts connections on lis until Shutdown is called.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpc.Serve(lis)
+}
+
+// ServeMetrics serves Prometheus metrics over HTTP on lis until Shutdown
+// is called.
+func (s *Server) ServeMetrics(lis net.Listener) error {
+	if err := s.metrics.Serve(lis); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops the server. It reports NOT_SERVING to health checks,
+// lets in-flight calls finish until ctx is done and then cuts off the
+// rest. It returns ctx's error if calls had to be cut off.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.stopMonitors()
+	s.health.Shutdown()
+	if s.blog != nil {
+		s.blog.Drain()
+	}
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+	var err error
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpc.Stop()
+		<-stopped
+		err = ctx.Err()
+	}
+	// Metrics stay up until the last call is accounted for.
+	s.metrics.Close()
+	return err
+}
+
+// Close releases the services' resources, such as the Mongo client. Call
+// it after Shutdown, when no handler can use them anymore.
+func (s *Server) Close(ctx context.Context) error {
+	if s.blog != nil {
+		if err := s.blog.Close(ctx); err != nil {
+			return fmt.Errorf("closing the blog store: %v", err)
+		}
+	}
+	return nil
+}
+
+// Main runs the server called name, configured from defaults and the
+// command line as config.Load describes, until it gets SIGINT or SIGTERM.
+func Main(name string, defaults config.Config) {
+	cfg, err := config.Load(name, defaults, os.Args[1:])
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		fatal("Invalid configuration", err)
+	}
+	slog.SetDefault(logging.New(os.Stdout, cfg.Logging))
+
+	flushTraces, err := tracing.Setup(context.TODO(), name, cfg.Tracing)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+	s, err := New(context.TODO(), cfg)
+	if err != nil {
+		fatal("Failed to start", err)
+	}
+	lis, err := net.Listen("tcp", cfg.Listen)
+	if err != nil {
+		fatal("Failed to listen", err)
+	}
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(lis)
+	}()
+	slog.Info("Serving", "address", lis.Addr().String())
+	if cfg.Metrics.Listen != "" {
+		metricsLis, err := net.Listen("tcp", cfg.Metrics.Listen)
+		if err != nil {
+			fatal("Failed to listen for metrics", err)
+		}
+		go func() {
+			if err := s.ServeMetrics(metricsLis); err != nil {
+				slog.Error("Failed serving metrics", "error", err)
+			}
+		}()
+		slog.Info("Serving metrics", "url", fmt.Sprintf("http://%v/metrics", metricsLis.Addr()))
+	}
+
+	//Wait for Ctr + C or a termination request
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+
+	//Block until a signal is received
+	select {
+	case sig := <-ch:
+		slog.Info("Stopping the server", "signal", sig.String(), "timeout", cfg.ShutdownTimeout.String())
+	case err := <-served:
+		slog.Error("Server stopped serving", "error", err)
+	}
+	// A second signal skips the wait.
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	go func() {
+		select {
+		case <-ch:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	start := time.Now()
+	if err := s.Shutdown(ctx); err != nil {
+		slog.Warn("Forced stop, in-flight calls were cut off", "after", time.Since(start).Round(time.Millisecond).String(), "error", err)
+	} else {
+		slog.Info("Stopped gracefully", "after", time.Since(start).Round(time.Millisecond).String())
+	}
+	cancel()
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	if err := s.Close(closeCtx); err != nil {
+		slog.Error("Failed releasing resources", "error", err)
+	}
+	// Spans of the last calls and of closing go out last.
+	if err := flushTraces(closeCtx); err != nil {
+		slog.Error("Failed flushing traces", "error", err)
+	}
+	slog.Info("End of program")
+}
+
+// fatal logs err and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
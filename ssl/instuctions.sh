@@ -1,5 +1,7 @@
 
 #!/bin/bash
+# Superseded by the Go tool, which needs no openssl and also issues client
+# certificates for mutual TLS: go run ./certs/gen_certs (see its -help).
 # Inspired from: https://github.com/grpc/grpc-java/tree/master/examples#generating-self-signed-certificates-for-use-with-grpc
 
 # Output files
@@ -0,0 +1,237 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataCarrier lets the propagator read and write gRPC metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// rpcAttributes describes a call to fullMethod, "/pkg.Service/Method".
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	service, method := "", strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(method, "/"); i >= 0 {
+		service, method = method[:i], method[i+1:]
+	}
+	return []attribute.KeyValue{
+		semconv.RPCSystemGRPC,
+		semconv.RPCServiceKey.String(service),
+		semconv.RPCMethodKey.String(method),
+	}
+}
+
+// spanName is the name of the span of a call to fullMethod.
+func spanName(fullMethod string) string {
+	return strings.TrimPrefix(fullMethod, "/")
+}
+
+// end finishes span with the status of err.
+func end(span trace.Span, err error) {
+	st, _ := status.FromError(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(st.Code())))
+	if err != nil {
+		span.SetStatus(codes.Error, st.Message())
+	}
+	span.End()
+}
+
+// messageEvent records a stream message on span.
+func messageEvent(span trace.Span, sent bool, id int) {
+	typ := "RECEIVED"
+	if sent {
+		typ = "SENT"
+	}
+	span.AddEvent("message", trace.WithAttributes(
+		semconv.MessageTypeKey.String(typ),
+		semconv.MessageIDKey.Int(id),
+	))
+}
+
+// startServerSpan starts the span of an incoming call, continuing the
+// trace the caller sent, if any.
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md.Copy()))
+	return tracer().Start(ctx, spanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(rpcAttributes(fullMethod)...),
+	)
+}
+
+// UnaryServerInterceptor traces unary calls.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		end(span, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor traces streaming calls, with an event per
+// message.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx, span: span})
+		end(span, err)
+		return err
+	}
+}
+
+// serverStream carries the call's span in its context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx            context.Context
+	span           trace.Span
+	sent, received int
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+		messageEvent(s.span, true, s.sent)
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received++
+		messageEvent(s.span, false, s.received)
+	}
+	return err
+}
+
+// DialOptions returns the options that make a client connection trace
+// its calls.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor()),
+	}
+}
+
+// startClientSpan starts the span of an outgoing call and adds its trace
+// context to the call's metadata.
+func startClientSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	ctx, span := tracer().Start(ctx, spanName(method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(rpcAttributes(method)...),
+	)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// UnaryClientInterceptor traces unary calls and passes their trace on to
+// the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := startClientSpan(ctx, method)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		end(span, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor traces streaming calls and passes their trace
+// on to the server. The span ends when receiving from the stream fails,
+// io.EOF counting as success.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := startClientSpan(ctx, method)
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			end(span, err)
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, span: span, serverStreams: desc.ServerStreams}, nil
+	}
+}
+
+// clientStream ends the call's span once the call is over.
+type clientStream struct {
+	grpc.ClientStream
+	span trace.Span
+	// serverStreams tells whether the response is a stream, which ends
+	// with io.EOF, or a single message.
+	serverStreams  bool
+	sent, received int
+	finished       bool
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+		messageEvent(s.span, true, s.sent)
+	}
+	return err
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		s.received++
+		messageEvent(s.span, false, s.received)
+		if !s.serverStreams {
+			s.finish(nil)
+		}
+	case err == io.EOF:
+		s.finish(nil)
+	default:
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *clientStream) finish(err error) {
+	if !s.finished {
+		s.finished = true
+		end(s.span, err)
+	}
+}
@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MongoMonitor returns a command monitor that records a client span for
+// every command the Mongo driver sends, as a child of the span in the
+// command's context. That includes the getMore batches of cursors, so
+// time spent iterating results shows up too. Command bodies are left
+// out, as they hold user data.
+func MongoMonitor() *event.CommandMonitor {
+	var spans sync.Map // request ID -> trace.Span
+	finish := func(requestID int64, failure string) {
+		value, ok := spans.LoadAndDelete(requestID)
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		if failure != "" {
+			span.SetStatus(codes.Error, failure)
+		}
+		span.End()
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			attrs := []attribute.KeyValue{
+				semconv.DBSystemMongoDB,
+				semconv.DBNameKey.String(evt.DatabaseName),
+				semconv.DBOperationKey.String(evt.CommandName),
+			}
+			// The first element of most commands names the collection.
+			if first, err := evt.Command.IndexErr(0); err == nil {
+				if collection, ok := first.Value().StringValueOK(); ok {
+					attrs = append(attrs, semconv.DBMongoDBCollectionKey.String(collection))
+				}
+			}
+			_, span := tracer().Start(ctx, "mongo."+evt.CommandName,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			spans.Store(evt.RequestID, span)
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.RequestID, "")
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.RequestID, evt.Failure)
+		},
+	}
+}
@@ -0,0 +1,170 @@
+// Package tracing records OpenTelemetry spans for gRPC calls and the
+// database commands they issue, and propagates trace context between
+// clients and servers in gRPC metadata.
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/shivkumar123g/grpc_go_course/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names the tracer of this package.
+const instrumentationName = "github.com/shivkumar123g/grpc_go_course/tracing"
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs the global tracer provider and propagator for the
+// process called serviceName, exporting spans as cfg says. The returned
+// function flushes pending spans and must be called before exiting.
+// With the "none" exporter no spans are recorded, but trace context is
+// still passed on.
+func Setup(ctx context.Context, serviceName string, cfg config.Tracing) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating OTLP exporter: %v", err)
+		}
+		exporter = exp
+	case "stdout":
+		exporter = newWriterExporter(os.Stdout, nil)
+	case "file":
+		f, err := os.OpenFile(cfg.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("opening trace file: %v", err)
+		}
+		exporter = newWriterExporter(f, f)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// SetupClient is Setup for the client program called name, configured
+// from the environment as config.TracingFromEnv describes.
+func SetupClient(ctx context.Context, name string) (func(context.Context) error, error) {
+	cfg, err := config.TracingFromEnv(name)
+	if err != nil {
+		return nil, err
+	}
+	return Setup(ctx, name, cfg)
+}
+
+// writerExporter writes spans as JSON lines, for looking at traces
+// without a collector.
+type writerExporter struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+func newWriterExporter(w io.Writer, closer io.Closer) *writerExporter {
+	return &writerExporter{enc: json.NewEncoder(w), closer: closer}
+}
+
+// spanJSON is the form spans are written in.
+type spanJSON struct {
+	Name         string                 `json:"name"`
+	Service      string                 `json:"service,omitempty"`
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	Kind         string                 `json:"kind"`
+	Start        time.Time              `json:"start"`
+	DurationMs   float64                `json:"duration_ms"`
+	Status       string                 `json:"status"`
+	Error        string                 `json:"error,omitempty"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Events       []eventJSON            `json:"events,omitempty"`
+}
+
+type eventJSON struct {
+	Name       string                 `json:"name"`
+	Time       time.Time              `json:"time"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+func (e *writerExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, span := range spans {
+		out := spanJSON{
+			Name:       span.Name(),
+			TraceID:    span.SpanContext().TraceID().String(),
+			SpanID:     span.SpanContext().SpanID().String(),
+			Kind:       span.SpanKind().String(),
+			Start:      span.StartTime(),
+			DurationMs: float64(span.EndTime().Sub(span.StartTime())) / float64(time.Millisecond),
+			Status:     span.Status().Code.String(),
+			Error:      span.Status().Description,
+			Attributes: make(map[string]interface{}),
+		}
+		if parent := span.Parent(); parent.IsValid() {
+			out.ParentSpanID = parent.SpanID().String()
+		}
+		if name, ok := span.Resource().Set().Value(semconv.ServiceNameKey); ok {
+			out.Service = name.AsString()
+		}
+		for _, kv := range span.Attributes() {
+			out.Attributes[string(kv.Key)] = kv.Value.AsInterface()
+		}
+		for _, event := range span.Events() {
+			ev := eventJSON{Name: event.Name, Time: event.Time}
+			if len(event.Attributes) > 0 {
+				ev.Attributes = make(map[string]interface{})
+				for _, kv := range event.Attributes {
+					ev.Attributes[string(kv.Key)] = kv.Value.AsInterface()
+				}
+			}
+			out.Events = append(out.Events, ev)
+		}
+		if err := e.enc.Encode(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *writerExporter) Shutdown(ctx context.Context) error {
+	if e.closer == nil {
+		return nil
+	}
+	return e.closer.Close()
+}